@@ -0,0 +1,452 @@
+/*
+	Unicode-correct Strings:
+
+		A Go string is bytes, a rune is one code point, but what a person calls
+		"one character" (a grapheme cluster) can be several runes:
+
+		Text		Bytes	Runes	Graphemes
+		"GoLang"	6		6		6
+		"🚀"		4		1		1
+		"é" (e + ◌́)	3		2		1		(combining accent)
+		"👩‍💻"		11		3		1		(woman + zero-width joiner + laptop)
+		"🇮🇳"		8		2		1		(two regional indicator letters)
+
+		Reversing or cutting a string by bytes or runes splits those apart, so
+		these helpers work on graphemes instead:
+
+		Graphemes, Len, Reverse, Truncate, DisplayWidth, PadRight, PadLeft,
+		Fold (case folding), ComposeLatin / DecomposeLatin, Slug.
+
+		ComposeLatin and DecomposeLatin are NFC and NFD for Latin letters with
+		the common accents only (the table below); other precomposed characters
+		pass through unchanged, so they are not full Unicode normalization.
+		Transliteration has the same limit. Full tables live in
+		golang.org/x/text/unicode/norm, which these lessons don't depend on.
+
+		Run the tests (emoji ZWJ sequences, flags, combining accents) with:
+
+			go test main.go main_test.go
+*/
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zwj = '\u200D' // zero-width joiner
+)
+
+// isExtend reports whether r attaches to the previous character instead of
+// starting a new grapheme: combining marks, variation selectors, emoji skin
+// tones, tag characters (used in subdivision flags) and Hangul vowel/final jamo.
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(r >= 0xFE00 && r <= 0xFE0F) ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) ||
+		(r >= 0xE0020 && r <= 0xE007F) ||
+		(r >= 0x1160 && r <= 0x11FF)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isPictographic approximates the Extended_Pictographic property: the emoji
+// and symbol blocks that ZWJ sequences are built from.
+func isPictographic(r rune) bool {
+	switch {
+	case isRegionalIndicator(r):
+		return false
+	case r >= 0x1F000 && r <= 0x1FAFF,
+		r >= 0x1FC00 && r <= 0x1FFFD,
+		r >= 0x2600 && r <= 0x27BF,
+		r >= 0x2300 && r <= 0x23FF,
+		r >= 0x2B00 && r <= 0x2BFF,
+		r >= 0x2190 && r <= 0x21FF,
+		r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122,
+		r == 0x2139, r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	}
+	return false
+}
+
+// Graphemes splits s into user-perceived characters. It follows the main
+// rules of Unicode text segmentation (UAX #29): CR LF stays together, marks
+// and modifiers extend the previous character, ZWJ joins emoji, and regional
+// indicators pair up into flags.
+//
+// A ZWJ only joins two emoji (rule GB11: pictographic, extenders, ZWJ,
+// pictographic). "a" + ZWJ + "b" stays two characters, the ZWJ going with "a".
+func Graphemes(s string) []string {
+	var out []string
+	start := 0
+	var prev rune = -1
+	riCount := 0      // regional indicators in a row, to pair them up
+	emoji := false    // the cluster so far is a pictograph plus extenders
+	emojiZWJ := false // ... followed by a ZWJ, so the next pictograph joins
+
+	for i, r := range s {
+		if i == 0 {
+			prev = r
+			if isRegionalIndicator(r) {
+				riCount = 1
+			}
+			emoji = isPictographic(r)
+			continue
+		}
+
+		join := false
+		switch {
+		case prev == '\r' && r == '\n':
+			join = true
+		case isExtend(r) || r == zwj:
+			join = true
+		case prev == zwj && emojiZWJ && isPictographic(r):
+			join = true // emoji ZWJ sequence: 👩 + ZWJ + 💻
+		case isRegionalIndicator(prev) && isRegionalIndicator(r) && riCount%2 == 1:
+			join = true
+		}
+
+		switch {
+		case r == zwj:
+			emojiZWJ = emoji
+			emoji = false
+		case isExtend(r):
+			emojiZWJ = false
+		default:
+			emoji = isPictographic(r)
+			emojiZWJ = false
+		}
+
+		if isRegionalIndicator(r) {
+			riCount++
+		} else {
+			riCount = 0
+		}
+
+		if !join {
+			out = append(out, s[start:i])
+			start = i
+			if isRegionalIndicator(r) {
+				riCount = 1
+			}
+		}
+		prev = r
+	}
+	if start < len(s) {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+// Len counts user-perceived characters.
+func Len(s string) int {
+	return len(Graphemes(s))
+}
+
+// Reverse reverses s by grapheme, so accents stay on their letters and emoji
+// sequences stay whole.
+func Reverse(s string) string {
+	g := Graphemes(s)
+	for i, j := 0, len(g)-1; i < j; i, j = i+1, j-1 {
+		g[i], g[j] = g[j], g[i]
+	}
+	return strings.Join(g, "")
+}
+
+// Truncate keeps at most n graphemes. If it cuts, the last ones are replaced
+// by ellipsis, so the result is never longer than n graphemes; an ellipsis
+// longer than n is cut too. A negative n counts as 0.
+func Truncate(s string, n int, ellipsis string) string {
+	n = max(n, 0)
+	g := Graphemes(s)
+	if len(g) <= n {
+		return s
+	}
+	e := Graphemes(ellipsis)
+	if len(e) >= n {
+		return strings.Join(e[:n], "")
+	}
+	return strings.Join(g[:n-len(e)], "") + ellipsis
+}
+
+// DisplayWidth returns how many terminal columns s takes up. A grapheme is
+// as wide as its widest rune: emoji and CJK are 2, everything else 1.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, g := range Graphemes(s) {
+		w := 0
+		for _, r := range g {
+			w = max(w, runeWidth(r))
+		}
+		width += w
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case isExtend(r) || r == zwj || unicode.Is(unicode.Cf, r):
+		return 0
+	case isRegionalIndicator(r),
+		r >= 0x1100 && r <= 0x115F,
+		r >= 0x2E80 && r <= 0xA4CF,
+		r >= 0xAC00 && r <= 0xD7A3,
+		r >= 0xF900 && r <= 0xFAFF,
+		r >= 0xFF00 && r <= 0xFF60,
+		r >= 0x1F300 && r <= 0x1FAFF,
+		r >= 0x20000 && r <= 0x3FFFD:
+		return 2
+	}
+	return 1
+}
+
+// PadRight adds spaces until s is width columns wide.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-DisplayWidth(s), 0))
+}
+
+// PadLeft adds spaces in front until s is width columns wide.
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-DisplayWidth(s), 0)) + s
+}
+
+// specialFolds are case foldings that change length or aren't plain lowercase.
+var specialFolds = map[rune]string{
+	'ß': "ss", 'ẞ': "ss", 'ς': "σ", 'ﬁ': "fi", 'ﬂ': "fl", 'ﬀ': "ff", 'İ': "i\u0307",
+}
+
+// Fold returns a case-folded copy of s for caseless comparison:
+// Fold("STRASSE") == Fold("straße").
+func Fold(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if f, ok := specialFolds[r]; ok {
+			sb.WriteString(f)
+			continue
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// Combining marks used by the composition table.
+const (
+	grave      = '\u0300'
+	acute      = '\u0301'
+	circumflex = '\u0302'
+	tilde      = '\u0303'
+	macron     = '\u0304'
+	breve      = '\u0306'
+	dotAbove   = '\u0307'
+	diaeresis  = '\u0308'
+	ringAbove  = '\u030A'
+	doubleAcut = '\u030B'
+	caron      = '\u030C'
+	cedilla    = '\u0327'
+	ogonek     = '\u0328'
+)
+
+// compositions lists, per mark, the base letters and their precomposed forms,
+// in matching order.
+var compositions = []struct {
+	mark     rune
+	bases    string
+	composed string
+}{
+	{grave, "aeiouAEIOU", "àèìòùÀÈÌÒÙ"},
+	{acute, "aeiouycnszAEIOUYCNSZ", "áéíóúýćńśźÁÉÍÓÚÝĆŃŚŹ"},
+	{circumflex, "aeiouAEIOU", "âêîôûÂÊÎÔÛ"},
+	{tilde, "anoANO", "ãñõÃÑÕ"},
+	{macron, "aeiouAEIOU", "āēīōūĀĒĪŌŪ"},
+	{breve, "agAG", "ăğĂĞ"},
+	{dotAbove, "zZI", "żŻİ"},
+	{diaeresis, "aeiouyAEIOUY", "äëïöüÿÄËÏÖÜŸ"},
+	{ringAbove, "auAU", "åůÅŮ"},
+	{doubleAcut, "ouOU", "őűŐŰ"},
+	{caron, "cdenrstzCDENRSTZ", "čďěňřšťžČĎĚŇŘŠŤŽ"},
+	{cedilla, "csCS", "çşÇŞ"},
+	{ogonek, "aeAE", "ąęĄĘ"},
+}
+
+var (
+	decomposeTable = map[rune][2]rune{} // é -> e, ◌́
+	composeTable   = map[[2]rune]rune{} // e, ◌́ -> é
+	combiningClass = map[rune]int{cedilla: 202, ogonek: 202}
+)
+
+func init() {
+	for _, c := range compositions {
+		bases, composed := []rune(c.bases), []rune(c.composed)
+		for i := range bases {
+			decomposeTable[composed[i]] = [2]rune{bases[i], c.mark}
+			composeTable[[2]rune{bases[i], c.mark}] = composed[i]
+		}
+	}
+}
+
+// class returns the canonical combining class used to order marks
+// (230 = above, 202 = attached below, 0 = not a mark).
+func class(r rune) int {
+	if c, ok := combiningClass[r]; ok {
+		return c
+	}
+	if unicode.Is(unicode.Mn, r) {
+		return 230
+	}
+	return 0
+}
+
+// DecomposeLatin is NFD for the Latin letters in compositions: "é" (1 rune)
+// -> "e" + U+0301 (2 runes). Runs of combining marks are put in canonical
+// order. Characters outside the table are left as they are.
+func DecomposeLatin(s string) string {
+	var runes []rune
+	for _, r := range s {
+		runes = appendDecomposed(runes, r)
+	}
+
+	// Canonical ordering: stable sort each run of marks by combining class.
+	for i := 0; i < len(runes); {
+		if class(runes[i]) == 0 {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && class(runes[j]) != 0 {
+			j++
+		}
+		marks := runes[i:j]
+		sort.SliceStable(marks, func(a, b int) bool { return class(marks[a]) < class(marks[b]) })
+		i = j
+	}
+	return string(runes)
+}
+
+func appendDecomposed(runes []rune, r rune) []rune {
+	if d, ok := decomposeTable[r]; ok {
+		return append(appendDecomposed(runes, d[0]), d[1])
+	}
+	return append(runes, r)
+}
+
+// ComposeLatin is NFC for the Latin letters in compositions: letters and
+// accents become single runes where the table has a precomposed form,
+// "e" + U+0301 -> "é".
+func ComposeLatin(s string) string {
+	runes := []rune(DecomposeLatin(s))
+	var out []rune
+	starter := -1 // index in out of the last non-mark rune
+	lastClass := 0
+
+	for _, r := range runes {
+		c := class(r)
+		if starter >= 0 && c != 0 && (lastClass == 0 || lastClass < c) {
+			if composed, ok := composeTable[[2]rune{out[starter], r}]; ok {
+				out[starter] = composed
+				continue
+			}
+		}
+		if c == 0 {
+			starter = len(out)
+			lastClass = 0
+		} else {
+			lastClass = c
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// transliterations are letters with no decomposition that still have a
+// common ASCII spelling.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'ø': "o", 'Ø': "O", 'œ': "oe", 'Œ': "OE",
+	'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D", 'þ': "th", 'Þ': "TH", 'ı': "i",
+	'&': " and ",
+}
+
+// Slug turns a title into a URL-friendly identifier:
+// "Crème Brûlée & Café!" -> "creme-brulee-and-cafe".
+func Slug(s string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range DecomposeLatin(s) {
+		if t, ok := transliterations[r]; ok {
+			for _, tr := range strings.ToLower(t) {
+				dash = writeSlugRune(&sb, tr, dash)
+			}
+			continue
+		}
+		if unicode.Is(unicode.Mn, r) {
+			continue // drop accents
+		}
+		dash = writeSlugRune(&sb, unicode.ToLower(r), dash)
+	}
+	return strings.Trim(sb.String(), "-")
+}
+
+// writeSlugRune writes ASCII letters and digits and turns anything else into
+// a single dash. It returns whether the last thing written was a dash.
+func writeSlugRune(sb *strings.Builder, r rune, dash bool) bool {
+	if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+		sb.WriteRune(r)
+		return false
+	}
+	if !dash && sb.Len() > 0 {
+		sb.WriteByte('-')
+	}
+	return true
+}
+
+func main() {
+
+	samples := []string{
+		"GoLang",
+		"🚀",
+		"e\u0301",                // e + combining acute accent
+		"👩\u200D💻",               // woman technologist (ZWJ sequence)
+		"👨\u200D👩\u200D👧\u200D👦", // family (3 ZWJs)
+		"👍🏽",                     // thumbs up + skin tone
+		"🇮🇳",                     // flag: regional indicators I + N
+		"한국어",
+	}
+	fmt.Printf("%-14s %5s %5s %9s %5s\n", "Text", "Bytes", "Runes", "Graphemes", "Width")
+	for _, s := range samples {
+		fmt.Printf("%s %5d %5d %9d %5d\n", PadRight(s, 14), len(s), utf8.RuneCountInString(s), Len(s), DisplayWidth(s))
+	}
+
+	text := "Cafe\u0301 👩\u200D💻 🇮🇳!"
+	fmt.Println("Reverse by runes:    ", reverseRunes(text))
+	fmt.Println("Reverse by graphemes:", Reverse(text))
+	fmt.Println("Truncate to 6:       ", Truncate(text, 6, "…"))
+
+	fmt.Printf("Padded: [%s] [%s]\n", PadRight("日本", 6), PadLeft("🚀", 4))
+
+	fmt.Println("Fold:", Fold("STRASSE") == Fold("straße"), Fold("ΣΊΣΥΦΟΣ"))
+
+	composed := "\u00E9"    // U+00E9
+	decomposed := "e\u0301" // U+0065 U+0301
+	fmt.Println("Same bytes?", composed == decomposed)
+	fmt.Println("Same after composing?", ComposeLatin(composed) == ComposeLatin(decomposed))
+	fmt.Printf("DecomposeLatin(%q) = %+q\n", "Ångström", DecomposeLatin("Ångström"))
+	fmt.Printf("ComposeLatin(%+q) = %q\n", DecomposeLatin("Ångström"), ComposeLatin(DecomposeLatin("Ångström")))
+
+	fmt.Println("Slug:", Slug("Crème Brûlée & Café!"))
+	fmt.Println("Slug:", Slug("Łódź – Straße 42"))
+}
+
+// reverseRunes is the naive reversal, shown for comparison.
+func reverseRunes(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"ascii", "Go", []string{"G", "o"}},
+		{"combining acute", "Cafe\u0301", []string{"C", "a", "f", "e\u0301"}},
+		{"two marks", "a\u0301\u0327b", []string{"a\u0301\u0327", "b"}},
+		{"mark at start", "\u0301a", []string{"\u0301", "a"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"woman technologist", "👩\u200D💻", []string{"👩\u200D💻"}},
+		{"family", "👨\u200D👩\u200D👧\u200D👦!", []string{"👨\u200D👩\u200D👧\u200D👦", "!"}},
+		{"heart on fire (VS16 before ZWJ)", "❤\uFE0F\u200D🔥", []string{"❤\uFE0F\u200D🔥"}},
+		{"rainbow flag", "🏳\uFE0F\u200D🌈", []string{"🏳\uFE0F\u200D🌈"}},
+		{"skin tone then ZWJ", "🧑🏽\u200D🚀", []string{"🧑🏽\u200D🚀"}},
+		{"zwj between letters", "a\u200Db", []string{"a\u200D", "b"}},
+		{"zwj then letter after emoji", "👩\u200Db", []string{"👩\u200D", "b"}},
+		{"letter zwj emoji", "a\u200D💻", []string{"a\u200D", "💻"}},
+		{"flags pair up", "🇮🇳🇯🇵🇫", []string{"🇮🇳", "🇯🇵", "🇫"}},
+		{"skin tone", "👍🏽👍", []string{"👍🏽", "👍"}},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		if got := Graphemes(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Graphemes(%+q) = %+q, want %+q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestReverseAndTruncate(t *testing.T) {
+	if got, want := Reverse("Cafe\u0301 👩\u200D💻"), "👩\u200D💻 e\u0301faC"; got != want {
+		t.Errorf("Reverse = %+q, want %+q", got, want)
+	}
+	if got, want := Reverse("🇮🇳🇯🇵"), "🇯🇵🇮🇳"; got != want {
+		t.Errorf("Reverse flags = %+q, want %+q", got, want)
+	}
+	tests := []struct {
+		in       string
+		n        int
+		ellipsis string
+		want     string
+	}{
+		{"Cafe\u0301 au lait", 4, "", "Cafe\u0301"},
+		{"Cafe\u0301 au lait", 5, "…", "Cafe\u0301…"},
+		{"👨\u200D👩\u200D👧 family", 2, "…", "👨\u200D👩\u200D👧…"},
+		{"short", 10, "…", "short"},
+		{"abc", 1, "...", "."},
+		{"abcd", 3, "...", "..."},
+		{"abcd", 3, "…", "ab…"},
+		{"abc", 0, "…", ""},
+		{"abc", -1, "", ""},
+		{"abc", -1, "…", ""},
+		{"", -1, "…", ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.in, tt.n, tt.ellipsis); got != tt.want {
+			t.Errorf("Truncate(%+q, %d, %q) = %+q, want %+q", tt.in, tt.n, tt.ellipsis, got, tt.want)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		in    string
+		len   int
+		width int
+	}{
+		{"GoLang", 6, 6},
+		{"e\u0301", 1, 1},
+		{"👩\u200D💻", 1, 2},
+		{"🇮🇳", 1, 2},
+		{"日本", 2, 4},
+		{"한국어", 3, 6},
+	}
+	for _, tt := range tests {
+		if got := Len(tt.in); got != tt.len {
+			t.Errorf("Len(%+q) = %d, want %d", tt.in, got, tt.len)
+		}
+		if got := DisplayWidth(tt.in); got != tt.width {
+			t.Errorf("DisplayWidth(%+q) = %d, want %d", tt.in, got, tt.width)
+		}
+	}
+	if got := PadRight("日本", 6); got != "日本  " {
+		t.Errorf("PadRight = %q", got)
+	}
+	if got := PadLeft("e\u0301", 3); got != "  e\u0301" {
+		t.Errorf("PadLeft = %+q", got)
+	}
+}
+
+func TestFoldAndCompose(t *testing.T) {
+	if Fold("STRASSE") != Fold("straße") {
+		t.Error("Fold: STRASSE != straße")
+	}
+	if Fold("ΣΊΣΥΦΟΣ") != Fold("σίσυφος") {
+		t.Error("Fold: final sigma")
+	}
+
+	if got := DecomposeLatin("\u00E9"); got != "e\u0301" {
+		t.Errorf("DecomposeLatin(é) = %+q", got)
+	}
+	if got := ComposeLatin("e\u0301"); got != "\u00E9" {
+		t.Errorf("ComposeLatin(e + acute) = %+q", got)
+	}
+	// Marks in non-canonical order still compose: cedilla (202) sorts first
+	// and composes; there is no precomposed c-cedilla-acute in the table.
+	if got := ComposeLatin("c\u0301\u0327"); got != "ç\u0301" {
+		t.Errorf("ComposeLatin(c + acute + cedilla) = %+q, want %+q", got, "ç\u0301")
+	}
+	for _, s := range []string{"Ångström", "Crème Brûlée", "Łódź", "naïve"} {
+		if got := ComposeLatin(DecomposeLatin(s)); got != s {
+			t.Errorf("round trip %q -> %+q", s, got)
+		}
+	}
+	// Outside the Latin table nothing changes.
+	if got := DecomposeLatin("\u1E31"); got != "\u1E31" {
+		t.Errorf("DecomposeLatin(U+1E31) = %+q, want it unchanged", got)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Crème Brûlée & Café!": "creme-brulee-and-cafe",
+		"Łódź – Straße 42":     "lodz-strasse-42",
+		"  --Hello,   World--": "hello-world",
+		"👩\u200D💻 Go":          "go",
+		"":                     "",
+	}
+	for in, want := range tests {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+/*
+	Complex Numbers:
+
+		complex(3, 4) builds 3+4i; real() and imag() take it apart.
+		The math/cmplx package has Abs, Phase, Polar and Rect, and this lesson
+		adds the pieces around them:
+
+		Function			Example
+		Polar / Rect		3+4i <-> magnitude 5, angle 0.927 rad (53.13°)
+		Parse				"3+4i", "3 - 4j", "(1.5e3-2i)", "-i", "7"
+		Format				precision, i or j, rectangular or polar (5∠53.13°)
+							(the zero FormatOptions rounds to integers;
+							DefaultFormat keeps the shortest exact digits)
+		FFT / IFFT			discrete Fourier transform of []complex128
+
+		FFT uses the radix-2 Cooley-Tukey algorithm when the length is a power
+		of two (8, 1024, ...) and Bluestein's algorithm for any other length,
+		so both run in O(n log n). DFT is the direct O(n²) formula, used here
+		to check the fast versions.
+
+		Run the tests (known transforms, FFT against DFT, Parse/Format round
+		trips) with:
+
+			go test main.go main_test.go
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// Polar returns the magnitude and the angle in radians (-π, π].
+func Polar(z complex128) (magnitude, angle float64) {
+	return cmplx.Abs(z), cmplx.Phase(z)
+}
+
+// Rect builds a complex number from a magnitude and an angle in radians.
+func Rect(magnitude, angle float64) complex128 {
+	return cmplx.Rect(magnitude, angle)
+}
+
+func Degrees(radians float64) float64 { return radians * 180 / math.Pi }
+func Radians(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+var ErrSyntax = errors.New("invalid complex number")
+
+// ParseError points at the part of the input that is not a number.
+type ParseError struct {
+	Input  string
+	Offset int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %q: %s at offset %d", e.Input, e.Msg, e.Offset)
+}
+
+func (e *ParseError) Unwrap() error { return ErrSyntax }
+
+// Parse reads "a+bi" with optional parentheses and spaces. Engineers' j is
+// accepted for i, and either part may be left out ("4i", "-2").
+func Parse(s string) (complex128, error) {
+	// Work on the text without spaces, but remember each byte's offset in s.
+	var text []byte
+	var offsets []int
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' && s[i] != '\t' {
+			text = append(text, s[i])
+			offsets = append(offsets, i)
+		}
+	}
+	fail := func(at int, msg string) error {
+		if at >= len(offsets) {
+			return &ParseError{s, len(s), msg}
+		}
+		return &ParseError{s, offsets[at], msg}
+	}
+
+	start, end := 0, len(text)
+	if end > 0 && text[0] == '(' {
+		if text[end-1] != ')' {
+			return 0, fail(end, "missing ')'")
+		}
+		start, end = 1, end-1
+	}
+	if start == end {
+		return 0, fail(start, "empty input")
+	}
+
+	// The imaginary part starts at the last sign that isn't the first
+	// character or part of an exponent (1e-3).
+	split := start
+	for i := end - 1; i > start; i-- {
+		if (text[i] == '+' || text[i] == '-') && text[i-1] != 'e' && text[i-1] != 'E' {
+			split = i
+			break
+		}
+	}
+
+	var re, im float64
+	hasImag := text[end-1] == 'i' || text[end-1] == 'j'
+	realEnd := end
+	if hasImag {
+		imagText := string(text[split : end-1])
+		switch imagText {
+		case "", "+":
+			im = 1
+		case "-":
+			im = -1
+		default:
+			v, err := strconv.ParseFloat(imagText, 64)
+			if err != nil {
+				return 0, fail(split, fmt.Sprintf("bad imaginary part %q", imagText))
+			}
+			im = v
+		}
+		realEnd = split
+	} else if split != start {
+		return 0, fail(end-1, "imaginary part must end in i or j")
+	}
+
+	if realEnd > start {
+		v, err := strconv.ParseFloat(string(text[start:realEnd]), 64)
+		if err != nil {
+			return 0, fail(start, fmt.Sprintf("bad real part %q", text[start:realEnd]))
+		}
+		re = v
+	}
+	return complex(re, im), nil
+}
+
+// Shortest is the Precision that prints the fewest digits that still read
+// back as the same float64.
+const Shortest = -1
+
+// FormatOptions control Format. The zero value rounds both parts to whole
+// numbers (3.7+4.2i prints as "4+4i"); start from DefaultFormat to keep
+// every digit.
+type FormatOptions struct {
+	Precision int  // digits after the point, or Shortest
+	Unit      byte // 'i' (default) or 'j'
+	Polar     bool // print magnitude∠angle instead of a+bi
+	Degrees   bool // polar angle in degrees instead of radians
+}
+
+// DefaultFormat prints like fmt does, without the parentheses: "3.7+4.2i".
+var DefaultFormat = FormatOptions{Precision: Shortest}
+
+// Format writes z with the given options.
+func Format(z complex128, opts FormatOptions) string {
+	num := func(f float64) string {
+		if opts.Precision < 0 {
+			// Any negative precision means Shortest, as in strconv.
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+		return strconv.FormatFloat(f, 'f', opts.Precision, 64)
+	}
+
+	if opts.Polar {
+		r, theta := Polar(z)
+		if opts.Degrees {
+			return num(r) + "∠" + num(Degrees(theta)) + "°"
+		}
+		return num(r) + "∠" + num(theta)
+	}
+
+	unit := opts.Unit
+	if unit == 0 {
+		unit = 'i'
+	}
+	im := num(imag(z))
+	sign := "+"
+	if strings.HasPrefix(im, "-") {
+		sign, im = "-", im[1:]
+	}
+	return num(real(z)) + sign + im + string(unit)
+}
+
+// FFT returns the discrete Fourier transform of x:
+//
+//	X[k] = Σ x[n]·e^(-2πi·kn/N)
+func FFT(x []complex128) []complex128 {
+	return transform(x, false)
+}
+
+// IFFT is the inverse of FFT: IFFT(FFT(x)) == x (up to rounding).
+func IFFT(x []complex128) []complex128 {
+	out := transform(x, true)
+	scale := complex(1/float64(len(x)), 0)
+	for i := range out {
+		out[i] *= scale
+	}
+	return out
+}
+
+func transform(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	switch {
+	case n == 0:
+		return nil
+	case n&(n-1) == 0:
+		out := append([]complex128(nil), x...)
+		radix2(out, inverse)
+		return out
+	}
+	return bluestein(x, inverse)
+}
+
+// radix2 transforms a in place; len(a) must be a power of two.
+func radix2(a []complex128, inverse bool) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+
+	// Reorder to bit-reversed indices, so the butterflies can work in place.
+	shift := 64 - bits.Len(uint(n-1))
+	for i := range a {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even, odd := a[start+k], w*a[start+k+size/2]
+				a[start+k] = even + odd
+				a[start+k+size/2] = even - odd
+				w *= step
+			}
+		}
+	}
+}
+
+// bluestein handles any length by rewriting the DFT as a convolution,
+// which is then done with power-of-two FFTs:
+//
+//	kn = (k² + n² - (k-n)²) / 2
+func bluestein(x []complex128, inverse bool) []complex128 {
+	n := len(x)
+	m := 1 << bits.Len(uint(2*n-2)) // power of two >= 2n-1
+
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	chirp := make([]complex128, n) // e^(sign·πi·k²/n)
+	for k := range chirp {
+		// k² mod 2n keeps the angle small, so large n stays accurate.
+		k2 := (k * k) % (2 * n)
+		chirp[k] = cmplx.Rect(1, sign*math.Pi*float64(k2)/float64(n))
+	}
+
+	a := make([]complex128, m)
+	for k := range x {
+		a[k] = x[k] * chirp[k]
+	}
+	b := make([]complex128, m)
+	b[0] = cmplx.Conj(chirp[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(chirp[k])
+		b[m-k] = b[k]
+	}
+
+	radix2(a, false)
+	radix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	radix2(a, true)
+
+	out := make([]complex128, n)
+	for k := range out {
+		out[k] = a[k] * chirp[k] / complex(float64(m), 0)
+	}
+	return out
+}
+
+// DFT is the direct O(n²) transform, kept as a reference for FFT.
+func DFT(x []complex128) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		for j, v := range x {
+			out[k] += v * cmplx.Rect(1, -2*math.Pi*float64(k*j%n)/float64(n))
+		}
+	}
+	return out
+}
+
+// maxError returns the largest difference between two slices.
+func maxError(a, b []complex128) float64 {
+	worst := 0.0
+	for i := range a {
+		worst = max(worst, cmplx.Abs(a[i]-b[i]))
+	}
+	return worst
+}
+
+func formatAll(xs []complex128) string {
+	parts := make([]string, len(xs))
+	for i, x := range xs {
+		// Round away -0 and 1e-16 noise so the output is readable.
+		re, im := math.Round(real(x)*1e9)/1e9+0, math.Round(imag(x)*1e9)/1e9+0
+		parts[i] = Format(complex(re, im), DefaultFormat)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+func main() {
+
+	z := complex(3, 4)
+	fmt.Println("z =", z, "real:", real(z), "imag:", imag(z))
+
+	r, theta := Polar(z)
+	fmt.Printf("Polar: magnitude %.2f, angle %.4f rad (%.2f°)\n", r, theta, Degrees(theta))
+	fmt.Println("Back to rectangular:", Format(Rect(r, theta), FormatOptions{Precision: 2}))
+	fmt.Println("Rect(2, 90°):", Format(Rect(2, Radians(90)), FormatOptions{Precision: 3}))
+
+	examples := []struct {
+		name string
+		opts FormatOptions
+	}{
+		{"default", DefaultFormat},
+		{"zero value", FormatOptions{}},
+		{"2 digits, j", FormatOptions{Precision: 2, Unit: 'j'}},
+		{"polar, degrees", FormatOptions{Precision: 2, Polar: true, Degrees: true}},
+		{"polar, radians", FormatOptions{Precision: 3, Polar: true}},
+	}
+	for _, ex := range examples {
+		fmt.Printf("Format 3.25-4.5i (%s): %s\n", ex.name, Format(complex(3.25, -4.5), ex.opts))
+	}
+
+	for _, s := range []string{"3+4i", "3 - 4j", "(1.5e3-2i)", "-i", "7", "2.5e-1i", "3+4", "3+xi", "(1+i"} {
+		c, err := Parse(s)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("Parse(%q) = %v\n", s, c)
+	}
+
+	fmt.Println("Known transforms:")
+	fmt.Println("  FFT[1 1 1 1]  =", formatAll(FFT([]complex128{1, 1, 1, 1})))  // all energy at frequency 0
+	fmt.Println("  FFT[1 0 0 0]  =", formatAll(FFT([]complex128{1, 0, 0, 0})))  // an impulse has every frequency
+	fmt.Println("  FFT[0 1 0 -1] =", formatAll(FFT([]complex128{0, 1, 0, -1}))) // one sine wave
+	fmt.Println("  FFT[1 2 3]    =", formatAll(FFT([]complex128{1, 2, 3})))     // length 3: Bluestein
+	fmt.Println("  IFFT[4 0 0 0] =", formatAll(IFFT([]complex128{4, 0, 0, 0})))
+
+	// A 50 Hz + 120 Hz signal sampled at 1000 Hz for 1000 samples (not a power of two).
+	const rate, n = 1000.0, 1000
+	signal := make([]complex128, n)
+	for i := range signal {
+		t := float64(i) / rate
+		signal[i] = complex(math.Sin(2*math.Pi*50*t)+0.5*math.Sin(2*math.Pi*120*t), 0)
+	}
+	spectrum := FFT(signal)
+	fmt.Print("Peaks in a 50 Hz + 120 Hz signal:")
+	for k := 1; k < n/2; k++ {
+		if amp := cmplx.Abs(spectrum[k]) * 2 / n; amp > 0.1 {
+			fmt.Printf(" %.0f Hz (amplitude %.2f)", float64(k)*rate/n, amp)
+		}
+	}
+	fmt.Println()
+
+	for _, size := range []int{8, 12, 100, 1024} {
+		x := make([]complex128, size)
+		for i := range x {
+			x[i] = complex(math.Cos(float64(i*i)), float64(i%7)-3)
+		}
+		fmt.Printf("n=%4d: |FFT-DFT| = %.1e, |IFFT(FFT(x))-x| = %.1e\n",
+			size, maxError(FFT(x), DFT(x)), maxError(IFFT(FFT(x)), x))
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func closeTo(a, b []complex128) bool {
+	return len(a) == len(b) && maxError(a, b) < tolerance
+}
+
+func TestFFTKnownTransforms(t *testing.T) {
+	s3 := math.Sqrt(3) / 2
+	tests := []struct {
+		name string
+		in   []complex128
+		want []complex128
+	}{
+		{"constant", []complex128{1, 1, 1, 1}, []complex128{4, 0, 0, 0}},
+		{"impulse", []complex128{1, 0, 0, 0}, []complex128{1, 1, 1, 1}},
+		{"shifted impulse", []complex128{0, 1, 0, 0}, []complex128{1, -1i, -1, 1i}},
+		{"sine", []complex128{0, 1, 0, -1}, []complex128{0, -2i, 0, 2i}},
+		{"alternating", []complex128{1, -1, 1, -1, 1, -1, 1, -1}, []complex128{0, 0, 0, 0, 8, 0, 0, 0}},
+		{"length 1", []complex128{5 - 2i}, []complex128{5 - 2i}},
+		{"length 2", []complex128{3, 1}, []complex128{4, 2}},
+		{"length 3 (Bluestein)", []complex128{1, 2, 3}, []complex128{6, complex(-1.5, s3), complex(-1.5, -s3)}},
+		{"length 5 constant (Bluestein)", []complex128{2, 2, 2, 2, 2}, []complex128{10, 0, 0, 0, 0}},
+		{"length 6 impulse (Bluestein)", []complex128{1, 0, 0, 0, 0, 0}, []complex128{1, 1, 1, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		if got := FFT(tt.in); !closeTo(got, tt.want) {
+			t.Errorf("%s: FFT(%v) = %v, want %v", tt.name, tt.in, got, tt.want)
+		}
+		if got := IFFT(tt.want); !closeTo(got, tt.in) {
+			t.Errorf("%s: IFFT(%v) = %v, want %v", tt.name, tt.want, got, tt.in)
+		}
+	}
+	if got := FFT(nil); got != nil {
+		t.Errorf("FFT(nil) = %v, want nil", got)
+	}
+}
+
+func TestFFTMatchesDFT(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 7, 8, 12, 31, 64, 100, 1000, 1024} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Cos(float64(i*i)), float64(i%7)-3)
+		}
+		if err := maxError(FFT(x), DFT(x)); err > tolerance*float64(n) {
+			t.Errorf("n=%d: |FFT-DFT| = %.1e", n, err)
+		}
+		if err := maxError(IFFT(FFT(x)), x); err > tolerance {
+			t.Errorf("n=%d: |IFFT(FFT(x))-x| = %.1e", n, err)
+		}
+	}
+}
+
+func TestFFTDoesNotModifyInput(t *testing.T) {
+	for _, x := range [][]complex128{{1, 2, 3, 4}, {1, 2, 3}} {
+		orig := append([]complex128(nil), x...)
+		FFT(x)
+		IFFT(x)
+		if !closeTo(x, orig) {
+			t.Errorf("input changed from %v to %v", orig, x)
+		}
+	}
+}
+
+func TestPolarRect(t *testing.T) {
+	r, theta := Polar(3 + 4i)
+	if r != 5 || math.Abs(theta-math.Atan2(4, 3)) > tolerance {
+		t.Errorf("Polar(3+4i) = %v, %v; want 5, %v", r, theta, math.Atan2(4, 3))
+	}
+	if z := Rect(2, Radians(90)); cmplx.Abs(z-2i) > tolerance {
+		t.Errorf("Rect(2, 90°) = %v, want 2i", z)
+	}
+	if d := Degrees(math.Pi); d != 180 {
+		t.Errorf("Degrees(π) = %v, want 180", d)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want complex128
+	}{
+		{"3+4i", 3 + 4i},
+		{"3 - 4j", 3 - 4i},
+		{"(1.5e3-2i)", 1500 - 2i},
+		{"-i", -1i},
+		{"+i", 1i},
+		{"i", 1i},
+		{"7", 7},
+		{"-2", -2},
+		{"2.5e-1i", 0.25i},
+		{"1e-3+1e+3i", complex(1e-3, 1e3)},
+		{"( 1 + 2i )", 1 + 2i},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	errs := []struct {
+		in     string
+		offset int
+	}{
+		{"", 0},
+		{"()", 1},
+		{"3+4", 2},
+		{"3+xi", 1},
+		{"(1+i", 4},
+		{"abc", 0},
+	}
+	for _, tt := range errs {
+		_, err := Parse(tt.in)
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q) error = %v, want a *ParseError wrapping ErrSyntax", tt.in, err)
+			continue
+		}
+		if pe.Offset != tt.offset {
+			t.Errorf("Parse(%q) offset = %d, want %d (%v)", tt.in, pe.Offset, tt.offset, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		z    complex128
+		opts FormatOptions
+		want string
+	}{
+		{3.7 + 4.2i, FormatOptions{}, "4+4i"},
+		{3.7 + 4.2i, DefaultFormat, "3.7+4.2i"},
+		{3 - 4i, DefaultFormat, "3-4i"},
+		{3 - 4i, FormatOptions{Precision: 2, Unit: 'j'}, "3.00-4.00j"},
+		{1e-20 + 1e20i, DefaultFormat, "1e-20+1e+20i"},
+		{3 + 4i, FormatOptions{Precision: 2, Polar: true, Degrees: true}, "5.00∠53.13°"},
+		{-1, FormatOptions{Precision: 3, Polar: true}, "1.000∠3.142"},
+		{complex(0, math.Inf(-1)), DefaultFormat, "0-Infi"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.z, tt.opts); got != tt.want {
+			t.Errorf("Format(%v, %+v) = %q, want %q", tt.z, tt.opts, got, tt.want)
+		}
+	}
+
+	// Anything Format prints with DefaultFormat parses back to the same value.
+	for _, z := range []complex128{0, 1 + 1i, -0.1 - 1e-7i, complex(math.Pi, -math.E), 1e300 + 1e-300i} {
+		s := Format(z, DefaultFormat)
+		if back, err := Parse(s); err != nil || back != z {
+			t.Errorf("Parse(Format(%v)) = %v, %v (text %q)", z, back, err, s)
+		}
+	}
+}
@@ -0,0 +1,643 @@
+/*
+	Structured Logging:
+
+		The lessons report failures like this:
+
+		fmt.Println("Error:", err)
+
+		which prints "Error: findItem: item not found" and nothing else: no
+		time, no level, no way to turn it off or search for it. log/slog writes
+		records with a level and key=value fields. This file adds what slog
+		leaves to you:
+
+		Piece				What it does
+		Levels				one level per subsystem: "info,db=debug,http=warn"
+		Sub(log, "db")		a logger for a subsystem ("db.pool" falls back to "db")
+		NewHandler			"json" or "logfmt" (slog's text format is logfmt)
+		Wrap(err, code)		an error carrying a code and the stack where it was made
+		errorHandler		expands any error field into err.msg, err.code, err.stack
+		Sampling			keeps the first N records per message each tick, then every Mth
+		Capture				a handler that stores records, for checking what was logged
+
+		Before:	fmt.Println("Error:", err)
+		After:	log.Error("load config", "err", err)
+
+		level=ERROR msg="load config" subsystem=config err.msg="open app.yaml: no such file"
+		err.code=CONFIG_MISSING err.stack="[main.loadConfig main.go:301 ...]"
+
+		Levels can be changed while the program runs; loggers already handed
+		out see the change.
+
+		Usage:
+			go run main.go					logfmt
+			go run main.go -format json
+			go run main.go -levels "warn,db=debug"
+			go test main.go main_test.go
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SubsystemKey is the attribute that names the part of the program logging.
+const SubsystemKey = "subsystem"
+
+// levelAll lets every record through the built-in handlers; Levels decides.
+const levelAll = slog.Level(math.MinInt)
+
+// Levels holds a default level and per-subsystem overrides.
+// It is safe for concurrent use and can be changed at any time.
+type Levels struct {
+	mu   sync.RWMutex
+	def  slog.Level
+	subs map[string]slog.Level
+}
+
+func NewLevels(def slog.Level) *Levels {
+	return &Levels{def: def, subs: map[string]slog.Level{}}
+}
+
+// ParseLevels reads "info,db=debug,http=warn": a bare level sets the
+// default, name=level sets one subsystem.
+func ParseLevels(spec string) (*Levels, error) {
+	l := NewLevels(slog.LevelInfo)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, text, found := strings.Cut(part, "=")
+		if !found {
+			name, text = "", part
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(text)); err != nil {
+			return nil, fmt.Errorf("levels %q: %w", part, err)
+		}
+		if name == "" {
+			l.SetDefault(level)
+		} else {
+			l.Set(name, level)
+		}
+	}
+	return l, nil
+}
+
+func (l *Levels) SetDefault(level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.def = level
+}
+
+func (l *Levels) Set(subsystem string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.subs[subsystem] = level
+}
+
+// Level is the level for subsystem. "db.pool" uses "db" when it has no
+// setting of its own, then the default.
+func (l *Levels) Level(subsystem string) slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for name := subsystem; name != ""; {
+		if level, ok := l.subs[name]; ok {
+			return level
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return l.def
+}
+
+func (l *Levels) String() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	parts := []string{strings.ToLower(l.def.String())}
+	for name, level := range l.subs {
+		parts = append(parts, name+"="+strings.ToLower(level.String()))
+	}
+	slices.Sort(parts[1:])
+	return strings.Join(parts, ",")
+}
+
+// levelHandler drops records below the level of its subsystem. The
+// subsystem comes from With(SubsystemKey, name); nested names are joined
+// with dots and written once per record, as a record field: after
+// WithGroup("req") it is logged as req.subsystem.
+type levelHandler struct {
+	next      slog.Handler
+	levels    *Levels
+	subsystem string
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.levels.Level(h.subsystem) && h.next.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.subsystem == "" {
+		return h.next.Handle(ctx, r)
+	}
+	// Put the subsystem first, ahead of the record's own fields.
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	out.AddAttrs(slog.String(SubsystemKey, h.subsystem))
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(a)
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	var rest []slog.Attr
+	for _, a := range attrs {
+		if a.Key == SubsystemKey {
+			c.subsystem = joinName(c.subsystem, a.Value.String())
+			continue
+		}
+		rest = append(rest, a)
+	}
+	if len(rest) > 0 {
+		c.next = h.next.WithAttrs(rest)
+	}
+	return &c
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	return &c
+}
+
+func joinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// Sub returns a logger for a subsystem of log: Sub(Sub(log, "db"), "pool")
+// logs as "db.pool".
+func Sub(log *slog.Logger, name string) *slog.Logger {
+	return log.With(SubsystemKey, name)
+}
+
+// CodedError carries a machine-readable code and the stack where it was
+// created. Wrap makes one.
+type CodedError struct {
+	Code string
+	Err  error
+	pcs  []uintptr
+}
+
+// Wrap attaches a code and the caller's stack to err. Wrap(nil, ...) is nil.
+func Wrap(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return &CodedError{Code: code, Err: err, pcs: pcs[:n]}
+}
+
+func (e *CodedError) Error() string     { return e.Err.Error() }
+func (e *CodedError) Unwrap() error     { return e.Err }
+func (e *CodedError) ErrorCode() string { return e.Code }
+
+// StackTrace lists "function file:line", innermost first, stopping at the
+// runtime's own frames.
+func (e *CodedError) StackTrace() []string {
+	var out []string
+	frames := runtime.CallersFrames(e.pcs)
+	for {
+		f, more := frames.Next()
+		if strings.HasPrefix(f.Function, "runtime.") {
+			break
+		}
+		file := f.File
+		if i := strings.LastIndex(file, "/"); i >= 0 {
+			file = file[i+1:]
+		}
+		out = append(out, fmt.Sprintf("%s %s:%d", f.Function, file, f.Line))
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// errorHandler turns an error field into a group with the message, plus
+// the code and stack of any wrapped error that has them. Any error type
+// can take part by having ErrorCode() string or StackTrace() []string.
+type errorHandler struct {
+	next slog.Handler
+}
+
+func (h *errorHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *errorHandler) Handle(ctx context.Context, r slog.Record) error {
+	expanded := false
+	r.Attrs(func(a slog.Attr) bool {
+		_, expanded = a.Value.Any().(error)
+		return !expanded
+	})
+	if !expanded {
+		return h.next.Handle(ctx, r)
+	}
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(expandError(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+func (h *errorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expandError(a)
+	}
+	return &errorHandler{h.next.WithAttrs(expanded)}
+}
+
+func (h *errorHandler) WithGroup(name string) slog.Handler {
+	return &errorHandler{h.next.WithGroup(name)}
+}
+
+func expandError(a slog.Attr) slog.Attr {
+	if a.Value.Kind() != slog.KindAny {
+		return a
+	}
+	err, ok := a.Value.Any().(error)
+	if !ok || err == nil {
+		return a
+	}
+	fields := []any{slog.String("msg", err.Error())}
+	var coded interface{ ErrorCode() string }
+	if errors.As(err, &coded) {
+		fields = append(fields, slog.String("code", coded.ErrorCode()))
+	}
+	var traced interface{ StackTrace() []string }
+	if errors.As(err, &traced) {
+		fields = append(fields, slog.Any("stack", traced.StackTrace()))
+	}
+	return slog.Group(a.Key, fields...)
+}
+
+// Sampling limits how often the same message is logged: in each Tick, the
+// first First records with a given level and message pass, then every
+// Thereafter-th one. Errors and above are never sampled.
+type Sampling struct {
+	First      int
+	Thereafter int // 0 drops everything after First
+	Tick       time.Duration
+}
+
+type sampler struct {
+	Sampling
+	mu      sync.Mutex
+	start   time.Time
+	counts  map[string]int
+	dropped atomic.Uint64
+}
+
+func (s *sampler) allow(r slog.Record) bool {
+	if r.Level >= slog.LevelError {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Time.Sub(s.start) >= s.Tick || r.Time.Before(s.start) {
+		s.start = r.Time
+		clear(s.counts)
+	}
+	key := r.Level.String() + "\x00" + r.Message
+	s.counts[key]++
+	n := s.counts[key]
+	if n <= s.First || (s.Thereafter > 0 && (n-s.First)%s.Thereafter == 0) {
+		return true
+	}
+	s.dropped.Add(1)
+	return false
+}
+
+// samplingHandler shares one sampler with every handler derived from it,
+// so With(...) loggers count against the same limits.
+type samplingHandler struct {
+	next    slog.Handler
+	sampler *sampler
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.sampler.allow(r) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{h.next.WithAttrs(attrs), h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{h.next.WithGroup(name), h.sampler}
+}
+
+// Dropped reports how many records sampling has thrown away, or 0 when the
+// logger does not sample.
+func Dropped(log *slog.Logger) uint64 {
+	for h := log.Handler(); ; {
+		switch v := h.(type) {
+		case *levelHandler:
+			h = v.next
+		case *samplingHandler:
+			return v.sampler.dropped.Load()
+		default:
+			return 0
+		}
+	}
+}
+
+var ErrUnknownFormat = errors.New("unknown log format")
+
+// NewHandler returns slog's JSON handler for "json" and its text handler for
+// "logfmt" (key=value pairs, values quoted when needed). Levels are left to
+// the Levels of the logger, so the handler itself lets everything through.
+func NewHandler(format string, w io.Writer, opts *slog.HandlerOptions) (slog.Handler, error) {
+	o := slog.HandlerOptions{Level: levelAll}
+	if opts != nil {
+		o.AddSource = opts.AddSource
+		o.ReplaceAttr = opts.ReplaceAttr
+	}
+	switch format {
+	case "json":
+		return slog.NewJSONHandler(w, &o), nil
+	case "logfmt", "text", "":
+		return slog.NewTextHandler(w, &o), nil
+	}
+	return nil, fmt.Errorf("%w %q (want json or logfmt)", ErrUnknownFormat, format)
+}
+
+// Config describes a logger. Handler wins over Format and Output.
+type Config struct {
+	Format    string    // "json" or "logfmt"
+	Output    io.Writer // default os.Stderr
+	Handler   slog.Handler
+	Levels    *Levels   // default: info everywhere
+	Sampling  *Sampling // nil: no sampling
+	AddSource bool
+	NoTime    bool // leave out the time, for stable output
+}
+
+// New builds a logger: subsystem levels, then sampling, then error
+// expansion, then the output handler.
+func New(cfg Config) (*slog.Logger, error) {
+	h := cfg.Handler
+	if h == nil {
+		out := cfg.Output
+		if out == nil {
+			out = os.Stderr
+		}
+		opts := &slog.HandlerOptions{AddSource: cfg.AddSource}
+		if cfg.NoTime {
+			opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+				return a
+			}
+		}
+		var err error
+		if h, err = NewHandler(cfg.Format, out, opts); err != nil {
+			return nil, err
+		}
+	}
+	h = &errorHandler{h}
+	if cfg.Sampling != nil {
+		s := *cfg.Sampling // a copy: the defaults must not change the caller's struct
+		if s.Tick <= 0 {
+			s.Tick = time.Second
+		}
+		h = &samplingHandler{h, &sampler{Sampling: s, counts: map[string]int{}}}
+	}
+	levels := cfg.Levels
+	if levels == nil {
+		levels = NewLevels(slog.LevelInfo)
+	}
+	return slog.New(&levelHandler{next: h, levels: levels}), nil
+}
+
+// Entry is a captured record with its fields flattened: a field "code"
+// in group "err" is Attrs["err.code"].
+type Entry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]any
+}
+
+// Capture is a handler that keeps every record instead of writing it, so
+// code can check what was logged. Handlers derived with With share the
+// same store.
+type Capture struct {
+	store  *captureStore
+	attrs  []slog.Attr
+	groups []string
+}
+
+type captureStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func NewCapture() *Capture {
+	return &Capture{store: &captureStore{}}
+}
+
+func (c *Capture) Enabled(context.Context, slog.Level) bool { return true }
+
+func (c *Capture) Handle(_ context.Context, r slog.Record) error {
+	e := Entry{Time: r.Time, Level: r.Level, Message: r.Message, Attrs: map[string]any{}}
+	for _, a := range c.attrs {
+		flatten(e.Attrs, "", a)
+	}
+	prefix := strings.Join(c.groups, ".")
+	r.Attrs(func(a slog.Attr) bool {
+		flatten(e.Attrs, prefix, a)
+		return true
+	})
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.entries = append(c.store.entries, e)
+	return nil
+}
+
+func (c *Capture) WithAttrs(attrs []slog.Attr) slog.Handler {
+	d := *c
+	d.attrs = slices.Clip(c.attrs)
+	prefix := strings.Join(c.groups, ".")
+	for _, a := range attrs {
+		if prefix != "" {
+			a = slog.Group(prefix, a)
+		}
+		d.attrs = append(d.attrs, a)
+	}
+	return &d
+}
+
+func (c *Capture) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return c
+	}
+	d := *c
+	d.groups = append(slices.Clip(c.groups), name)
+	return &d
+}
+
+func flatten(dst map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	key := a.Key
+	if prefix != "" {
+		key = prefix + "." + a.Key
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key == "" {
+			key = prefix
+		}
+		for _, g := range a.Value.Group() {
+			flatten(dst, key, g)
+		}
+		return
+	}
+	if a.Key != "" {
+		dst[key] = a.Value.Any()
+	}
+}
+
+// Entries returns a copy of everything captured so far.
+func (c *Capture) Entries() []Entry {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	return slices.Clone(c.store.entries)
+}
+
+// Find returns the first entry with the given message.
+func (c *Capture) Find(msg string) (Entry, bool) {
+	for _, e := range c.Entries() {
+		if e.Message == msg {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+func (c *Capture) Reset() {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.entries = nil
+}
+
+// The lesson functions, logging instead of printing.
+
+var ErrNotFound = errors.New("item not found")
+
+func findItem(id int) error {
+	if id != 1 {
+		return Wrap(fmt.Errorf("findItem %d: %w", id, ErrNotFound), "ITEM_NOT_FOUND")
+	}
+	return nil
+}
+
+func handlePanic(log *slog.Logger) {
+	if r := recover(); r != nil {
+		log.Error("recovered from panic", "panic", r)
+	}
+}
+
+func division(log *slog.Logger, num1, num2 int) {
+	defer handlePanic(log)
+	if num2 == 0 {
+		panic("Cannot divide a number by zero")
+	}
+	log.Info("divided", "num1", num1, "num2", num2, "result", num1/num2)
+}
+
+func main() {
+	format := flag.String("format", "logfmt", "json or logfmt")
+	levelSpec := flag.String("levels", "info,db=debug", "default level and subsystem=level pairs")
+	flag.Parse()
+
+	levels, err := ParseLevels(*levelSpec)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(2)
+	}
+	log, err := New(Config{Format: *format, Output: os.Stdout, Levels: levels, NoTime: true})
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(2)
+	}
+	fmt.Println("levels:", levels)
+
+	// Per-subsystem levels: db logs debug, http only warnings and up.
+	levels.Set("http", slog.LevelWarn)
+	db, httpLog := Sub(log, "db"), Sub(log, "http")
+	db.Debug("query", "sql", "SELECT 1", "took", 3*time.Millisecond)
+	Sub(db, "pool").Debug("connection opened", "open", 4)
+	httpLog.Info("request", "path", "/health") // below warn: dropped
+	httpLog.Warn("slow request", "path", "/report", "took", 2*time.Second)
+
+	// Errors: the code and stack come from the wrapped error.
+	if err := findItem(2); err != nil {
+		Sub(log, "store").Error("lookup failed", "err", err, "id", 2)
+	}
+
+	// handlePanic with a logger instead of fmt.Println("RECOVER", a).
+	division(log, 4, 2)
+	division(log, 8, 0)
+
+	// Sampling: a hot loop logs the same message 1000 times.
+	sampled, _ := New(Config{Format: *format, Output: os.Stdout, NoTime: true,
+		Sampling: &Sampling{First: 3, Thereafter: 250, Tick: time.Minute}})
+	for i := range 1000 {
+		sampled.Info("cache miss", "key", i)
+	}
+	fmt.Println("sampled out:", Dropped(sampled))
+
+	// Capture: check what was logged without parsing output.
+	capture := NewCapture()
+	test, _ := New(Config{Handler: capture})
+	Sub(test, "store").Error("lookup failed", "err", findItem(7))
+	Sub(test, "store").Debug("not captured: below info")
+	if e, ok := capture.Find("lookup failed"); ok {
+		fmt.Printf("captured %d record(s): level=%s subsystem=%v code=%v stack frames=%d\n",
+			len(capture.Entries()), e.Level, e.Attrs["subsystem"], e.Attrs["err.code"], len(e.Attrs["err.stack"].([]string)))
+		fmt.Println("errors.Is(ErrNotFound):", errors.Is(findItem(7), ErrNotFound))
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCaptured(t *testing.T, spec string, sampling *Sampling) (*slog.Logger, *Levels, *Capture) {
+	t.Helper()
+	levels, err := ParseLevels(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	capture := NewCapture()
+	log, err := New(Config{Handler: capture, Levels: levels, Sampling: sampling})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log, levels, capture
+}
+
+func TestLevels(t *testing.T) {
+	log, levels, capture := newCaptured(t, "info,db=debug", nil)
+	db := Sub(log, "db")
+	pool := Sub(db, "pool")
+
+	logged := func(l *slog.Logger, level slog.Level) bool {
+		capture.Reset()
+		l.Log(t.Context(), level, "msg")
+		return len(capture.Entries()) == 1
+	}
+	tests := []struct {
+		name   string
+		log    *slog.Logger
+		level  slog.Level
+		logged bool
+	}{
+		{"root info", log, slog.LevelInfo, true},
+		{"root debug", log, slog.LevelDebug, false},
+		{"db debug", db, slog.LevelDebug, true},
+		{"db.pool falls back to db", pool, slog.LevelDebug, true},
+		{"http uses the default", Sub(log, "http"), slog.LevelDebug, false},
+	}
+	for _, tt := range tests {
+		if got := logged(tt.log, tt.level); got != tt.logged {
+			t.Errorf("%s: logged = %v, want %v", tt.name, got, tt.logged)
+		}
+	}
+
+	pool.Debug("query")
+	if e, ok := capture.Find("query"); !ok || e.Attrs[SubsystemKey] != "db.pool" {
+		t.Errorf("subsystem = %v, want db.pool", e.Attrs[SubsystemKey])
+	}
+
+	// Changes apply to loggers handed out before them.
+	levels.Set("db", slog.LevelWarn)
+	if logged(db, slog.LevelInfo) || logged(pool, slog.LevelInfo) {
+		t.Error("db=warn: info records still logged")
+	}
+	levels.Set("db.pool", slog.LevelDebug)
+	if !logged(pool, slog.LevelDebug) || logged(db, slog.LevelDebug) {
+		t.Error("db.pool=debug: want pool at debug and db still at warn")
+	}
+	if got, want := levels.String(), "info,db.pool=debug,db=warn"; got != want {
+		t.Errorf("Levels.String() = %q, want %q", got, want)
+	}
+}
+
+func TestParseLevelsError(t *testing.T) {
+	if _, err := ParseLevels("info,db=loud"); err == nil {
+		t.Error(`ParseLevels("db=loud"): want an error`)
+	}
+}
+
+func TestErrorExpansion(t *testing.T) {
+	log, _, capture := newCaptured(t, "info", nil)
+	log.Error("lookup failed", "err", findItem(7), "id", 7)
+	log.Error("plain", "err", errors.New("boom"))
+
+	e, _ := capture.Find("lookup failed")
+	if e.Attrs["err.msg"] != "findItem 7: item not found" || e.Attrs["err.code"] != "ITEM_NOT_FOUND" || e.Attrs["id"] != int64(7) {
+		t.Errorf("attrs = %v, want err.msg, err.code and id", e.Attrs)
+	}
+	stack, _ := e.Attrs["err.stack"].([]string)
+	// Under go test package main is "command-line-arguments", not "main".
+	if len(stack) == 0 || !strings.Contains(stack[0], ".findItem main.go:") {
+		t.Errorf("err.stack = %q, want it to start at findItem", stack)
+	}
+
+	e, _ = capture.Find("plain")
+	if _, ok := e.Attrs["err.code"]; ok || e.Attrs["err.msg"] != "boom" {
+		t.Errorf("plain error attrs = %v, want only err.msg", e.Attrs)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	sampling := &Sampling{First: 3, Thereafter: 250}
+	log, _, capture := newCaptured(t, "info", sampling)
+	if sampling.Tick != 0 {
+		t.Errorf("New changed the caller's Sampling.Tick to %v", sampling.Tick)
+	}
+
+	for i := range 1000 {
+		Sub(log, "cache").Info("miss", "key", i)
+	}
+	log.Error("failed") // errors are never sampled
+	log.Error("failed")
+
+	var keys []int64
+	failed := 0
+	for _, e := range capture.Entries() {
+		switch e.Message {
+		case "miss":
+			keys = append(keys, e.Attrs["key"].(int64))
+		case "failed":
+			failed++
+		}
+	}
+	// Records 1-3 pass, then every 250th after them: 253, 503, 753.
+	want := []int64{0, 1, 2, 252, 502, 752}
+	if len(keys) != len(want) {
+		t.Fatalf("kept keys %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("kept keys %v, want %v", keys, want)
+		}
+	}
+	if failed != 2 || Dropped(log) != 994 {
+		t.Errorf("errors kept %d, dropped %d; want 2 and 994", failed, Dropped(log))
+	}
+}
+
+func TestSamplingTick(t *testing.T) {
+	capture := NewCapture()
+	h := &samplingHandler{capture, &sampler{Sampling: Sampling{First: 1, Tick: time.Minute}, counts: map[string]int{}}}
+	start := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	for _, at := range []time.Duration{0, time.Second, time.Minute, time.Minute + time.Second} {
+		h.Handle(t.Context(), slog.NewRecord(start.Add(at), slog.LevelInfo, "tick", 0))
+	}
+	if n := len(capture.Entries()); n != 2 {
+		t.Errorf("kept %d records, want the first of each minute (2)", n)
+	}
+}
+
+// The subsystem is a record field, so an open group holds it.
+func TestSubsystemInGroup(t *testing.T) {
+	log, _, capture := newCaptured(t, "info", nil)
+	Sub(log, "http").WithGroup("req").Info("served", "path", "/health")
+
+	e, _ := capture.Find("served")
+	if e.Attrs["req.subsystem"] != "http" || e.Attrs["req.path"] != "/health" {
+		t.Errorf("attrs = %v, want req.subsystem and req.path", e.Attrs)
+	}
+	if _, ok := e.Attrs[SubsystemKey]; ok {
+		t.Errorf("attrs = %v: subsystem also written outside the group", e.Attrs)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+	Worker Pool:
+
+		filterSlice and removeDuplicatesElement walk the slice one element at
+		a time on one CPU. When the work per element is heavy, a fixed number
+		of goroutines can share it:
+
+		Function			Result
+		ForEach				runs fn(i) for every index on the pool
+		ParallelMap			out[i] = fn(in[i]), in the input order
+		ParallelFilter		the elements keep() accepts, in the input order
+		ParallelReduce		combine(combine(a, b), c)..., chunk by chunk
+
+		Options:
+		Workers		goroutines at most (default GOMAXPROCS)
+		ChunkSize	elements a worker takes at a time (default: about 4 chunks per worker)
+		Errors		FirstError: stop at the first failure seen and return it
+					(with Workers: 1 that is the lowest failing index)
+					AllErrors: keep going, return every failure joined (*IndexError)
+
+		Every function takes a context: cancelling it stops the workers
+		between elements and returns ctx.Err(). A panic in fn becomes an error
+		for that element instead of crashing the program.
+
+		ParallelReduce needs an associative combine ((a+b)+c == a+(b+c)) and
+		its identity (0 for +, 1 for *). Chunks are combined left to right,
+		so combine does not have to be commutative (string concatenation works).
+
+		Usage:
+			go run main.go
+			go test -race main.go main_test.go				compare with sequential code, under the race detector
+			go test -bench . main.go main_test.go			sequential vs parallel timings
+*/
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ErrorMode int
+
+const (
+	FirstError ErrorMode = iota // cancel the rest and return the first error
+	AllErrors                   // process everything, return all errors joined
+)
+
+type Options struct {
+	Workers   int // <= 0: runtime.GOMAXPROCS(0)
+	ChunkSize int // <= 0: picked from the length and the number of workers
+	Errors    ErrorMode
+}
+
+// IndexError is the failure of one element.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error { return e.Err }
+
+// PanicError is a panic recovered from a worker.
+type PanicError struct {
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// plan works out the number of workers and the chunk size for n elements.
+func (o Options) plan(n int) (workers, chunk int) {
+	workers = o.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	chunk = o.ChunkSize
+	if chunk <= 0 {
+		// Several chunks per worker, so one slow chunk doesn't leave the
+		// others idle at the end.
+		chunk = n / (workers * 4)
+	}
+	// Clamped to n first, so the rounding up below cannot overflow.
+	chunk = max(1, min(chunk, n))
+	chunks := (n + chunk - 1) / chunk
+	return max(1, min(workers, chunks)), chunk
+}
+
+// ForEach calls fn for every index in [0, n) using at most opts.Workers
+// goroutines. Workers take chunks of indexes from a shared counter.
+func ForEach(ctx context.Context, n int, fn func(ctx context.Context, i int) error, opts Options) error {
+	if n <= 0 {
+		return ctx.Err()
+	}
+	workers, chunk := opts.plan(n)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     atomic.Int64
+		mu       sync.Mutex
+		errs     []*IndexError
+		firstErr *IndexError
+		wg       sync.WaitGroup
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		e := &IndexError{i, err}
+		if opts.Errors == AllErrors {
+			errs = append(errs, e)
+			return
+		}
+		// Several workers can fail before they see the cancel; keep the
+		// lowest index of those. That is not always the index a sequential
+		// loop would have stopped at: a lower failing element may sit in a
+		// chunk nobody started before the cancel. Only Workers: 1 is exact.
+		if firstErr == nil || i < firstErr.Index {
+			firstErr = e
+		}
+		cancel()
+	}
+	call := func(i int) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{r}
+			}
+		}()
+		return fn(ctx, i)
+	}
+
+	for range workers {
+		wg.Go(func() {
+			for {
+				start := int(next.Add(int64(chunk))) - chunk
+				if start >= n {
+					return
+				}
+				for i := start; i < min(start+chunk, n); i++ {
+					if ctx.Err() != nil {
+						return
+					}
+					if err := call(i); err != nil {
+						fail(i, err)
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b *IndexError) int { return cmp.Compare(a.Index, b.Index) })
+		joined := make([]error, len(errs), len(errs)+1)
+		for i, e := range errs {
+			joined[i] = e
+		}
+		// A cancelled caller is worth knowing too; Join drops the nil.
+		return errors.Join(append(joined, context.Cause(ctx))...)
+	}
+	// Only the caller's context can be done here: ours is cancelled by fail.
+	return context.Cause(ctx)
+}
+
+// ParallelMap returns fn applied to every element, in the input order.
+// On error the results computed so far are returned with it; elements that
+// failed or were never reached hold the zero value.
+func ParallelMap[T, R any](ctx context.Context, in []T, fn func(context.Context, T) (R, error), opts Options) ([]R, error) {
+	out := make([]R, len(in))
+	err := ForEach(ctx, len(in), func(ctx context.Context, i int) error {
+		r, err := fn(ctx, in[i])
+		if err != nil {
+			return err
+		}
+		out[i] = r
+		return nil
+	}, opts)
+	return out, err
+}
+
+// ParallelFilter returns the elements keep accepts, in the input order.
+// On error it returns nil and the error.
+func ParallelFilter[T any](ctx context.Context, in []T, keep func(context.Context, T) (bool, error), opts Options) ([]T, error) {
+	keepIt, err := ParallelMap(ctx, in, keep, opts)
+	if err != nil {
+		return nil, err
+	}
+	var out []T
+	for i, ok := range keepIt {
+		if ok {
+			out = append(out, in[i])
+		}
+	}
+	return out, nil
+}
+
+// ParallelReduce folds in with combine, which must be associative and have
+// identity as its neutral element. Each chunk is reduced by one worker, then
+// the chunk results are combined in order.
+func ParallelReduce[T any](ctx context.Context, in []T, identity T, combine func(T, T) T, opts Options) (T, error) {
+	_, chunk := opts.plan(len(in))
+	partial := make([]T, (len(in)+chunk-1)/chunk)
+	err := ForEach(ctx, len(partial), func(ctx context.Context, c int) error {
+		acc := identity
+		for _, v := range in[c*chunk : min((c+1)*chunk, len(in))] {
+			acc = combine(acc, v)
+		}
+		partial[c] = acc
+		return ctx.Err()
+	}, Options{Workers: opts.Workers, ChunkSize: 1, Errors: opts.Errors})
+	if err != nil {
+		return identity, err
+	}
+	acc := identity
+	for _, p := range partial {
+		acc = combine(acc, p)
+	}
+	return acc, nil
+}
+
+// Sequential versions from the slices questions, to compare against.
+
+func filterSlice(slices []int, limit int) []int {
+	var filterSlices []int
+	for _, value := range slices {
+		if value > limit {
+			filterSlices = append(filterSlices, value)
+		}
+	}
+	return filterSlices
+}
+
+// collatzSteps is deliberately CPU-bound work per element.
+func collatzSteps(n int) int {
+	steps := 0
+	for n > 1 {
+		if n%2 == 0 {
+			n /= 2
+		} else {
+			n = 3*n + 1
+		}
+		steps++
+	}
+	return steps
+}
+
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+var ErrNegative = errors.New("negative number")
+
+func sqrtInt(_ context.Context, n int) (int, error) {
+	if n < 0 {
+		return 0, ErrNegative
+	}
+	r := 0
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r, nil
+}
+
+func numbers(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i + 1
+	}
+	return out
+}
+
+func main() {
+	ctx := context.Background()
+	in := numbers(20)
+
+	steps, _ := ParallelMap(ctx, in, func(_ context.Context, v int) (int, error) { return collatzSteps(v), nil }, Options{Workers: 4})
+	fmt.Println("Collatz steps:", steps)
+
+	primes, _ := ParallelFilter(ctx, in, func(_ context.Context, v int) (bool, error) { return isPrime(v), nil }, Options{Workers: 4})
+	fmt.Println("Primes:", primes)
+	fmt.Println("filterSlice(>15):", filterSlice(in, 15))
+
+	sum, _ := ParallelReduce(ctx, in, 0, func(a, b int) int { return a + b }, Options{Workers: 3})
+	maxSteps, _ := ParallelReduce(ctx, steps, 0, func(a, b int) int { return max(a, b) }, Options{})
+	fmt.Println("Sum:", sum, "Max steps:", maxSteps)
+
+	// Errors: first error vs all of them.
+	mixed := []int{16, -4, 9, -1, 25}
+	roots, err := ParallelMap(ctx, mixed, sqrtInt, Options{Workers: 1})
+	fmt.Println("FirstError:", roots, err)
+	roots, err = ParallelMap(ctx, mixed, sqrtInt, Options{Errors: AllErrors})
+	fmt.Println("AllErrors: ", roots)
+	fmt.Println("Error:", err)
+	fmt.Println("errors.Is(err, ErrNegative):", errors.Is(err, ErrNegative))
+
+	// Cancellation: a timeout stops the workers.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = ParallelMap(ctx, numbers(1000), func(ctx context.Context, v int) (int, error) {
+		time.Sleep(time.Millisecond)
+		return v, nil
+	}, Options{Workers: 2})
+	fmt.Println("Timeout:", err)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// randomCase returns random input and options; run it with -race so the
+// detector sees the workers share the output slices.
+func randomCase(rng *rand.Rand, round int) ([]int, Options) {
+	in := make([]int, rng.IntN(2000))
+	for i := range in {
+		in[i] = rng.IntN(2000) - 1000
+	}
+	return in, Options{Workers: 1 + round%9, ChunkSize: rng.IntN(40)}
+}
+
+func TestMatchesSequential(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	ctx := context.Background()
+	for round := range 200 {
+		in, opts := randomCase(rng, round)
+		name := fmt.Sprintf("round %d (len %d, %+v)", round, len(in), opts)
+
+		got, err := ParallelMap(ctx, in, func(_ context.Context, v int) (int, error) { return v * v, nil }, opts)
+		want := make([]int, len(in))
+		for i, v := range in {
+			want[i] = v * v
+		}
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("ParallelMap %s: err %v, results differ from the sequential loop", name, err)
+		}
+
+		kept, err := ParallelFilter(ctx, in, func(_ context.Context, v int) (bool, error) { return v > 3, nil }, opts)
+		if err != nil || !slices.Equal(kept, filterSlice(in, 3)) {
+			t.Errorf("ParallelFilter %s: err %v, results differ from filterSlice", name, err)
+		}
+
+		sum, err := ParallelReduce(ctx, in, 0, func(a, b int) int { return a + b }, opts)
+		wantSum := 0
+		for _, v := range in {
+			wantSum += v
+		}
+		if err != nil || sum != wantSum {
+			t.Errorf("ParallelReduce sum %s = %d, %v; want %d", name, sum, err, wantSum)
+		}
+
+		// Concatenation is associative but not commutative: order must hold.
+		strs := make([]string, len(in)%50)
+		for i := range strs {
+			strs[i] = fmt.Sprint(i, ",")
+		}
+		joined, err := ParallelReduce(ctx, strs, "", func(a, b string) string { return a + b }, opts)
+		wantJoined := ""
+		for _, s := range strs {
+			wantJoined += s
+		}
+		if err != nil || joined != wantJoined {
+			t.Errorf("ParallelReduce concat %s = %q, %v; want %q", name, joined, err, wantJoined)
+		}
+	}
+}
+
+func TestAllErrors(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	for round := range 100 {
+		in, opts := randomCase(rng, round)
+		opts.Errors = AllErrors
+		var negatives []int
+		for i, v := range in {
+			if v < 0 {
+				negatives = append(negatives, i)
+			}
+		}
+
+		// One IndexError per negative element, in index order.
+		_, err := ParallelMap(context.Background(), in, sqrtInt, opts)
+		var indexes []int
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				var ie *IndexError
+				if !errors.As(e, &ie) || !errors.Is(e, ErrNegative) {
+					t.Fatalf("round %d: unexpected error %v", round, e)
+				}
+				indexes = append(indexes, ie.Index)
+			}
+		} else if err != nil {
+			t.Fatalf("round %d: err = %v, want joined IndexErrors", round, err)
+		}
+		if !slices.Equal(indexes, negatives) {
+			t.Errorf("round %d (%+v): error indexes %v, want %v", round, opts, indexes, negatives)
+		}
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	for round := range 100 {
+		in, opts := randomCase(rng, round)
+		firstNegative := slices.IndexFunc(in, func(v int) bool { return v < 0 })
+
+		var calls atomic.Int64
+		_, err := ParallelMap(context.Background(), in, func(ctx context.Context, v int) (int, error) {
+			calls.Add(1)
+			return sqrtInt(ctx, v)
+		}, opts)
+
+		if firstNegative < 0 {
+			if err != nil || calls.Load() != int64(len(in)) {
+				t.Errorf("round %d: err %v after %d of %d calls, want every call and no error", round, err, calls.Load(), len(in))
+			}
+			continue
+		}
+		// With several workers any failing element may be reported; it
+		// must still be one that failed.
+		var ie *IndexError
+		if !errors.As(err, &ie) || !errors.Is(err, ErrNegative) || in[ie.Index] >= 0 {
+			t.Errorf("round %d (%+v): err = %v, want an IndexError for a negative element", round, opts, err)
+			continue
+		}
+		if opts.Workers == 1 && ie.Index != firstNegative {
+			t.Errorf("round %d: one worker reported index %d, want the first negative at %d", round, ie.Index, firstNegative)
+		}
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var started atomic.Int64
+	err := ForEach(ctx, 1_000_000, func(ctx context.Context, i int) error {
+		if started.Add(1) == 100 {
+			cancel()
+		}
+		return nil
+	}, Options{Workers: 4})
+	if !errors.Is(err, context.Canceled) || started.Load() >= 1_000_000 {
+		t.Errorf("err = %v after %d calls, want context.Canceled before the end", err, started.Load())
+	}
+
+	// Cancelled before it starts: nothing runs.
+	var calls atomic.Int64
+	err = ForEach(ctx, 10, func(context.Context, int) error { calls.Add(1); return nil }, Options{})
+	if !errors.Is(err, context.Canceled) || calls.Load() != 0 {
+		t.Errorf("cancelled context: err = %v after %d calls", err, calls.Load())
+	}
+}
+
+func TestPanic(t *testing.T) {
+	_, err := ParallelMap(context.Background(), []int{1, 2, 0}, func(_ context.Context, v int) (int, error) { return 10 / v, nil }, Options{})
+	var pe *PanicError
+	var ie *IndexError
+	if !errors.As(err, &pe) || !errors.As(err, &ie) || ie.Index != 2 {
+		t.Errorf("err = %v, want a PanicError for element 2", err)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	ctx := context.Background()
+	if out, err := ParallelMap(ctx, []int(nil), sqrtInt, Options{}); err != nil || len(out) != 0 {
+		t.Errorf("ParallelMap(nil) = %v, %v", out, err)
+	}
+	if sum, err := ParallelReduce(ctx, []int(nil), 7, func(a, b int) int { return a + b }, Options{}); err != nil || sum != 7 {
+		t.Errorf("ParallelReduce(nil) = %d, %v; want the identity 7", sum, err)
+	}
+	// A chunk larger than the input is one chunk, not an overflow.
+	if sum, err := ParallelReduce(ctx, []int{1, 2, 3}, 0, func(a, b int) int { return a + b }, Options{ChunkSize: math.MaxInt}); err != nil || sum != 6 {
+		t.Errorf("ParallelReduce with ChunkSize MaxInt = %d, %v; want 6", sum, err)
+	}
+}
+
+// The benchmarks time sequential loops against the pool on CPU-bound work.
+
+var benchInput = numbers(200_000)
+
+func collatzStepsCtx(_ context.Context, v int) (int, error) { return collatzSteps(v), nil }
+
+func BenchmarkMapSequential(b *testing.B) {
+	for b.Loop() {
+		out := make([]int, len(benchInput))
+		for i, v := range benchInput {
+			out[i] = collatzSteps(v)
+		}
+	}
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	for _, w := range []int{2, 4, runtime.GOMAXPROCS(0)} {
+		b.Run(fmt.Sprintf("workers=%d", w), func(b *testing.B) {
+			for b.Loop() {
+				ParallelMap(context.Background(), benchInput, collatzStepsCtx, Options{Workers: w})
+			}
+		})
+	}
+}
+
+func BenchmarkFilterSequential(b *testing.B) {
+	for b.Loop() {
+		var out []int
+		for _, v := range benchInput {
+			if isPrime(v) {
+				out = append(out, v)
+			}
+		}
+	}
+}
+
+func BenchmarkParallelFilter(b *testing.B) {
+	for b.Loop() {
+		ParallelFilter(context.Background(), benchInput, func(_ context.Context, v int) (bool, error) { return isPrime(v), nil }, Options{})
+	}
+}
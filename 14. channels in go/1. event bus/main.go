@@ -0,0 +1,458 @@
+/*
+	Event Bus:
+
+		applyOperation calls the function it is given, once, right away:
+
+		applyOperation(45, 78, add)
+
+		An event bus keeps the functions (or channels) and calls every one
+		whose pattern matches when something is published:
+
+		bus := New[Order]()
+		bus.Handle("order.*", func(e Event[Order]) { ... }, Options{})
+		bus.Publish(ctx, "order.created", order)
+
+		The bus is generic, so each bus carries one payload type and handlers
+		get it without type assertions.
+
+		Topics are dot-separated. In a pattern:
+		*		matches one segment			order.* matches order.created
+		**		matches the rest (last only)	order.** matches order, order.paid.card
+
+		Every subscriber has its own buffered channel. When it is full the
+		subscriber's policy decides:
+
+		Policy		Full buffer
+		Drop		the event is skipped for that subscriber (counted in Dropped)
+		Block		Publish waits for room, or until its context is done
+		Disconnect	the subscriber is closed; Err() returns ErrSlowConsumer
+
+		NewSync builds a bus that calls handlers inside Publish, in
+		subscription order: deterministic, for tests.
+
+		Close stops new publishes, waits for the ones in flight, closes every
+		subscription and waits for handlers to finish what is buffered. A Block
+		publish still waiting for room gives up on that subscriber, so a
+		reader that stopped reading can't hang Close.
+
+		Run: go run main.go
+		Test: go test -race main.go main_test.go
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Event[T any] struct {
+	Topic   string
+	Payload T
+	Time    time.Time
+}
+
+type Policy int
+
+const (
+	Drop Policy = iota
+	Block
+	Disconnect
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Drop:
+		return "drop"
+	case Block:
+		return "block"
+	case Disconnect:
+		return "disconnect"
+	}
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+type Options struct {
+	Buffer int // channel size; 0 means 16
+	Policy Policy
+}
+
+var (
+	ErrClosed       = errors.New("bus closed")
+	ErrBadPattern   = errors.New("bad topic pattern")
+	ErrSlowConsumer = errors.New("subscriber too slow: disconnected")
+)
+
+// Bus delivers events of type T to the subscribers of matching topics.
+type Bus[T any] struct {
+	sync bool
+
+	mu     sync.RWMutex
+	subs   []*Subscription[T] // in subscription order
+	closed bool
+	nextID uint64
+
+	publishing sync.WaitGroup // Publish calls in progress
+	handlers   sync.WaitGroup // goroutines running Handle callbacks
+}
+
+// New returns a bus that delivers through each subscriber's channel.
+func New[T any]() *Bus[T] {
+	return &Bus[T]{}
+}
+
+// NewSync returns a bus whose handlers run inside Publish, one after the
+// other. Channel subscriptions still get buffered events.
+func NewSync[T any]() *Bus[T] {
+	return &Bus[T]{sync: true}
+}
+
+// Subscription is one subscriber. Read events from C, or pass a function
+// to Handle instead.
+type Subscription[T any] struct {
+	ID      uint64
+	Pattern string
+	Policy  Policy
+
+	bus     *Bus[T]
+	pattern []string
+	fn      func(Event[T]) // set for sync handlers, which have no channel
+	ch      chan Event[T]
+	done    chan struct{} // closed first on unsubscribe or Close, to free blocked publishers
+	stop    sync.Once     // closes done
+
+	mu     sync.Mutex // guards sends against closing ch; held while a Block send waits
+	closed bool
+
+	errMu   sync.Mutex // not mu, so Err doesn't wait behind a blocked send
+	err     error
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// C is the channel of events. It is closed on Unsubscribe, on disconnect
+// and on Close, after the events already in it. Handlers on a sync bus
+// have no channel.
+func (s *Subscription[T]) C() <-chan Event[T] { return s.ch }
+
+// Dropped counts events skipped because the buffer was full.
+func (s *Subscription[T]) Dropped() uint64 { return s.dropped.Load() }
+
+// Err is ErrSlowConsumer after a disconnect, otherwise nil.
+func (s *Subscription[T]) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+// Unsubscribe stops delivery. Events already buffered can still be read.
+// It is safe to call more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	s.once.Do(func() {
+		s.release()
+		s.bus.remove(s)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closeLocked(nil)
+	})
+}
+
+// release frees publishers waiting on a full Block buffer; the event they
+// were sending is not delivered to s.
+func (s *Subscription[T]) release() {
+	s.stop.Do(func() { close(s.done) })
+}
+
+func (s *Subscription[T]) closeLocked(err error) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.errMu.Lock()
+	s.err = err
+	s.errMu.Unlock()
+	if s.ch != nil {
+		close(s.ch)
+	}
+}
+
+// parsePattern checks a topic or pattern. Topics may not hold wildcards.
+func parsePattern(pattern string, wildcards bool) ([]string, error) {
+	parts := strings.Split(pattern, ".")
+	for i, p := range parts {
+		switch {
+		case p == "":
+			return nil, fmt.Errorf("%w %q: empty segment", ErrBadPattern, pattern)
+		case (p == "*" || p == "**") && !wildcards:
+			return nil, fmt.Errorf("%w %q: wildcards are for subscriptions only", ErrBadPattern, pattern)
+		case p == "**" && i != len(parts)-1:
+			return nil, fmt.Errorf("%w %q: ** must be the last segment", ErrBadPattern, pattern)
+		case p != "*" && p != "**" && strings.Contains(p, "*"):
+			return nil, fmt.Errorf("%w %q: * must be a whole segment", ErrBadPattern, pattern)
+		}
+	}
+	return parts, nil
+}
+
+func match(pattern, topic []string) bool {
+	for i, p := range pattern {
+		if p == "**" {
+			return true
+		}
+		if i >= len(topic) || (p != "*" && p != topic[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(topic)
+}
+
+func (b *Bus[T]) add(pattern string, opts Options, fn func(Event[T])) (*Subscription[T], error) {
+	parts, err := parsePattern(pattern, true)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 16
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	b.nextID++
+	s := &Subscription[T]{
+		ID: b.nextID, Pattern: pattern, Policy: opts.Policy,
+		bus: b, pattern: parts, done: make(chan struct{}),
+	}
+	if b.sync && fn != nil {
+		s.fn = fn
+	} else {
+		s.ch = make(chan Event[T], opts.Buffer)
+	}
+	if !b.sync && fn != nil {
+		b.handlers.Go(func() {
+			for e := range s.ch {
+				fn(e)
+			}
+		})
+	}
+	b.subs = append(b.subs, s)
+	return s, nil
+}
+
+// Subscribe returns a subscription whose events arrive on C().
+func (b *Bus[T]) Subscribe(pattern string, opts Options) (*Subscription[T], error) {
+	return b.add(pattern, opts, nil)
+}
+
+// Handle calls fn for every matching event: from its own goroutine, one
+// event at a time, or inside Publish on a sync bus.
+func (b *Bus[T]) Handle(pattern string, fn func(Event[T]), opts Options) (*Subscription[T], error) {
+	return b.add(pattern, opts, fn)
+}
+
+func (b *Bus[T]) remove(s *Subscription[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = slices.DeleteFunc(b.subs, func(x *Subscription[T]) bool { return x == s })
+}
+
+// Publish sends payload to every subscription matching topic. It returns
+// early with ctx's error only when a Block subscriber has no room.
+func (b *Bus[T]) Publish(ctx context.Context, topic string, payload T) error {
+	parts, err := parsePattern(topic, false)
+	if err != nil {
+		return err
+	}
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	b.publishing.Add(1)
+	var targets []*Subscription[T]
+	for _, s := range b.subs {
+		if match(s.pattern, parts) {
+			targets = append(targets, s)
+		}
+	}
+	b.mu.RUnlock()
+	defer b.publishing.Done()
+
+	e := Event[T]{Topic: topic, Payload: payload, Time: time.Now()}
+	for _, s := range targets {
+		if err := s.deliver(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Subscription[T]) deliver(ctx context.Context, e Event[T]) error {
+	if s.fn != nil {
+		select {
+		case <-s.done: // unsubscribed after Publish picked its targets
+		default:
+			s.fn(e)
+		}
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	switch s.Policy {
+	case Block:
+		select {
+		case s.ch <- e:
+		case <-s.done: // unsubscribed while we waited
+		case <-ctx.Done():
+			return fmt.Errorf("publish %s to subscriber %d: %w", e.Topic, s.ID, ctx.Err())
+		}
+	case Disconnect:
+		select {
+		case s.ch <- e:
+		default:
+			s.closeLocked(ErrSlowConsumer)
+			s.bus.remove(s)
+		}
+	default:
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+	return nil
+}
+
+// Close shuts the bus down gracefully: new publishes fail with ErrClosed,
+// publishes in flight finish, subscriptions are closed, and handlers run
+// until their buffers are empty. A publish blocked on a full Block buffer
+// skips that subscriber instead of waiting for room: nothing may ever read
+// it. If ctx ends first, Close returns its error and the handlers keep
+// draining in the background.
+func (b *Bus[T]) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	b.closed = true
+	// No subscription can be added now, so this is every one a publish in
+	// flight can be sending to.
+	subs := slices.Clone(b.subs)
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		for _, s := range subs {
+			s.release()
+		}
+		b.publishing.Wait()
+		for _, s := range subs {
+			s.Unsubscribe()
+		}
+		b.handlers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type Order struct {
+	ID     int
+	Amount float64
+}
+
+func main() {
+	ctx := context.Background()
+
+	// Sync mode: handlers run inside Publish, so the output order is fixed.
+	fmt.Println("-- sync bus")
+	orders := NewSync[Order]()
+	orders.Handle("order.created", func(e Event[Order]) {
+		fmt.Printf("  billing:  %s #%d %.2f\n", e.Topic, e.Payload.ID, e.Payload.Amount)
+	}, Options{})
+	audit, _ := orders.Handle("order.*", func(e Event[Order]) {
+		fmt.Printf("  audit:    %s #%d\n", e.Topic, e.Payload.ID)
+	}, Options{})
+	orders.Handle("**", func(e Event[Order]) {
+		fmt.Printf("  firehose: %s\n", e.Topic)
+	}, Options{})
+
+	orders.Publish(ctx, "order.created", Order{1, 99.5})
+	orders.Publish(ctx, "order.paid.card", Order{1, 99.5}) // * is one segment: audit skips it
+	audit.Unsubscribe()
+	orders.Publish(ctx, "order.cancelled", Order{2, 10})
+
+	if _, err := orders.Subscribe("order.**.paid", Options{}); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if err := orders.Publish(ctx, "order.*", Order{}); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Slow consumers: nobody reads these channels, buffers hold 2 events.
+	fmt.Println("-- slow consumer policies")
+	bus := New[int]()
+	dropper, _ := bus.Subscribe("tick", Options{Buffer: 2, Policy: Drop})
+	quitter, _ := bus.Subscribe("tick", Options{Buffer: 2, Policy: Disconnect})
+	for i := range 5 {
+		bus.Publish(ctx, "tick", i)
+	}
+	fmt.Println("  drop:       dropped", dropper.Dropped(), "events")
+	fmt.Println("  disconnect: err =", quitter.Err())
+	for v := range quitter.C() { // the buffered events are still there
+		fmt.Println("  disconnect: still got", v.Payload)
+	}
+
+	blocker, _ := bus.Subscribe("tick", Options{Buffer: 1, Policy: Block})
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	for i := range 3 {
+		if err := bus.Publish(timeout, "tick", i); err != nil {
+			fmt.Println("  block:      Error:", err)
+			break
+		}
+	}
+	blocker.Unsubscribe()
+	dropper.Unsubscribe()
+
+	// Graceful shutdown: every published event is handled before Close returns.
+	fmt.Println("-- graceful shutdown")
+	jobs := New[int]()
+	var handled atomic.Int64
+	jobs.Handle("job.*", func(e Event[int]) {
+		time.Sleep(time.Millisecond) // a slow handler
+		handled.Add(1)
+	}, Options{Buffer: 100, Policy: Block})
+
+	var publishers sync.WaitGroup
+	for p := range 3 {
+		publishers.Go(func() {
+			for i := range 20 {
+				jobs.Publish(ctx, fmt.Sprintf("job.%d", p), i)
+			}
+		})
+	}
+	publishers.Wait()
+	if err := jobs.Close(ctx); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("  handled", handled.Load(), "of 60 events")
+	if err := jobs.Publish(ctx, "job.1", 0); err != nil {
+		fmt.Println("  Error:", err)
+	}
+}
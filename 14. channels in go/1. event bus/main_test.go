@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWildcards(t *testing.T) {
+	bus := NewSync[int]()
+	got := map[string][]string{} // pattern -> topics it received
+	for _, pattern := range []string{"order.created", "order.*", "order.**", "*", "**", "order.*.card", "*.paid.*"} {
+		if _, err := bus.Handle(pattern, func(e Event[int]) { got[pattern] = append(got[pattern], e.Topic) }, Options{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, topic := range []string{"order", "order.created", "order.paid.card", "user.created"} {
+		if err := bus.Publish(context.Background(), topic, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := map[string][]string{
+		"order.created": {"order.created"},
+		"order.*":       {"order.created"},
+		"order.**":      {"order", "order.created", "order.paid.card"},
+		"*":             {"order"},
+		"**":            {"order", "order.created", "order.paid.card", "user.created"},
+		"order.*.card":  {"order.paid.card"},
+		"*.paid.*":      {"order.paid.card"},
+	}
+	for pattern, topics := range want {
+		if !slices.Equal(got[pattern], topics) {
+			t.Errorf("%q received %q, want %q", pattern, got[pattern], topics)
+		}
+	}
+}
+
+func TestBadPatterns(t *testing.T) {
+	bus := New[int]()
+	for _, pattern := range []string{"", "order.", "order..paid", "order.**.paid", "order.cre*"} {
+		if _, err := bus.Subscribe(pattern, Options{}); !errors.Is(err, ErrBadPattern) {
+			t.Errorf("Subscribe(%q): err = %v, want ErrBadPattern", pattern, err)
+		}
+	}
+	for _, topic := range []string{"order.*", "**", ""} {
+		if err := bus.Publish(context.Background(), topic, 0); !errors.Is(err, ErrBadPattern) {
+			t.Errorf("Publish(%q): err = %v, want ErrBadPattern", topic, err)
+		}
+	}
+}
+
+func TestDrop(t *testing.T) {
+	bus := New[int]()
+	sub, _ := bus.Subscribe("tick", Options{Buffer: 2, Policy: Drop})
+	for i := range 5 {
+		if err := bus.Publish(context.Background(), "tick", i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sub.Dropped() != 3 || sub.Err() != nil {
+		t.Errorf("Dropped() = %d, Err() = %v; want 3 and nil", sub.Dropped(), sub.Err())
+	}
+	sub.Unsubscribe()
+	var got []int
+	for e := range sub.C() {
+		got = append(got, e.Payload)
+	}
+	if !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("buffered events = %v, want [0 1]", got)
+	}
+}
+
+// A sync bus calls handlers inside Publish, in subscription order.
+func TestSyncOrder(t *testing.T) {
+	bus := NewSync[int]()
+	var got []string
+	for _, name := range []string{"a", "b", "c"} {
+		bus.Handle("n.*", func(e Event[int]) { got = append(got, fmt.Sprint(name, e.Payload)) }, Options{})
+	}
+	b, _ := bus.Handle("n.**", func(e Event[int]) { got = append(got, fmt.Sprint("d", e.Payload)) }, Options{})
+	for i := range 3 {
+		bus.Publish(context.Background(), "n.x", i)
+		if i == 1 {
+			b.Unsubscribe()
+		}
+	}
+	want := []string{"a0", "b0", "c0", "d0", "a1", "b1", "c1", "d1", "a2", "b2", "c2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("handler calls %q, want %q", got, want)
+	}
+}
+
+// Close returns after every buffered event has been handled or can be read.
+func TestCloseDrains(t *testing.T) {
+	bus := New[int]()
+	var handled atomic.Int64
+	bus.Handle("job.*", func(Event[int]) {
+		time.Sleep(100 * time.Microsecond)
+		handled.Add(1)
+	}, Options{Buffer: 200, Policy: Block})
+	sub, _ := bus.Subscribe("job.**", Options{Buffer: 200})
+
+	var publishers sync.WaitGroup
+	for p := range 4 {
+		publishers.Go(func() {
+			for i := range 50 {
+				if err := bus.Publish(context.Background(), fmt.Sprintf("job.%d", p), i); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+	publishers.Wait()
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if handled.Load() != 200 {
+		t.Errorf("handled %d of 200 events before Close returned", handled.Load())
+	}
+	read := 0
+	for range sub.C() {
+		read++
+	}
+	if read != 200 {
+		t.Errorf("read %d of 200 events from the closed channel", read)
+	}
+}
+
+func TestClosed(t *testing.T) {
+	bus := New[int]()
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(context.Background(), "tick", 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("Publish after Close = %v, want ErrClosed", err)
+	}
+	if _, err := bus.Subscribe("tick", Options{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Subscribe after Close = %v, want ErrClosed", err)
+	}
+	if err := bus.Close(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("second Close = %v, want ErrClosed", err)
+	}
+}
+
+// Close must not wait forever on a Block publisher whose reader is gone.
+func TestCloseReleasesBlocked(t *testing.T) {
+	bus := New[int]()
+	sub, _ := bus.Subscribe("tick", Options{Buffer: 1, Policy: Block})
+	published := make(chan error)
+	go func() {
+		for i := range 2 { // the second send waits, with no deadline
+			if err := bus.Publish(context.Background(), "tick", i); err != nil {
+				published <- err
+				return
+			}
+		}
+		published <- nil
+	}()
+	for len(sub.C()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond) // let the second Publish reach the send
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.Close(ctx); err != nil {
+		t.Fatalf("Close = %v, want nil", err)
+	}
+	if err := <-published; err != nil {
+		t.Errorf("Publish = %v, want nil", err)
+	}
+	if e, ok := <-sub.C(); !ok || e.Payload != 0 {
+		t.Errorf("buffered event = %v, %t; want 0", e.Payload, ok)
+	}
+}
+
+// Err must answer while a Block publisher is waiting for room.
+func TestErrWhileBlocked(t *testing.T) {
+	bus := New[int]()
+	sub, err := bus.Subscribe("tick", Options{Buffer: 1, Policy: Block})
+	if err != nil {
+		t.Fatal(err)
+	}
+	published := make(chan error)
+	go func() {
+		for i := range 2 { // the second send has no room and waits
+			if err := bus.Publish(context.Background(), "tick", i); err != nil {
+				published <- err
+				return
+			}
+		}
+		published <- nil
+	}()
+	for len(sub.C()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond) // let the second Publish reach the send
+
+	got := make(chan error)
+	go func() { got <- sub.Err() }()
+	select {
+	case err := <-got:
+		if err != nil {
+			t.Errorf("Err() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Err() blocked behind a waiting Publish")
+	}
+
+	sub.Unsubscribe() // frees the publisher
+	if err := <-published; err != nil {
+		t.Errorf("Publish = %v, want nil after Unsubscribe", err)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	bus := New[int]()
+	sub, _ := bus.Subscribe("tick", Options{Buffer: 2, Policy: Disconnect})
+	for i := range 3 {
+		bus.Publish(context.Background(), "tick", i)
+	}
+	if err := sub.Err(); !errors.Is(err, ErrSlowConsumer) {
+		t.Errorf("Err() = %v, want ErrSlowConsumer", err)
+	}
+	var got []int
+	for e := range sub.C() {
+		got = append(got, e.Payload)
+	}
+	if len(got) != 2 || got[0] != 0 || got[1] != 1 {
+		t.Errorf("buffered events = %v, want [0 1]", got)
+	}
+}
@@ -0,0 +1,437 @@
+/*
+	Decimal Numbers:
+
+		float64 stores numbers in binary, so most decimal fractions are
+		approximations: 0.1 is really 0.1000000000000000055511151231257827...
+		Adding such values drifts, which is why salary float64 = 50000.45 is a
+		bad idea in payroll code:
+
+		0.1 + 0.2				float64: 0.30000000000000004	Decimal: 0.3
+		50000.45 * 12 months	float64: 600005.3999999999		Decimal: 600005.40
+
+		Decimal stores an integer coefficient and a scale (digits after the point):
+
+		Value		Coefficient		Scale
+		123.45		12345			2
+		-0.007		-7				3
+		1200		1200			0
+
+		The coefficient is a math/big.Int, so there is no upper limit.
+		Add, Sub and Mul are exact. Div needs a scale and a RoundingMode,
+		because 1/3 has no exact decimal form.
+
+		Mode		2.345	2.355	-2.345
+		HalfUp		2.35	2.36	-2.35	(ties away from zero)
+		HalfEven	2.34	2.36	-2.34	(ties to even, "banker's rounding")
+		Down		2.34	2.35	-2.34	(toward zero)
+		Up			2.35	2.36	-2.35	(away from zero)
+		Floor		2.34	2.35	-2.35	(toward -infinity)
+		Ceil		2.35	2.36	-2.34	(toward +infinity)
+
+		Decimal works with encoding/json, encoding (text) and database/sql.
+		Parse rejects exponents beyond ±MaxExponent, so untrusted JSON or SQL
+		text can't ask for a billion-digit number.
+
+		Run the tests, compare with math/big, or fuzz Parse with:
+
+			go test main.go main_test.go
+			go test -bench . -benchmem main.go main_test.go
+			go test -fuzz FuzzParse main.go main_test.go
+*/
+
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number: coef × 10^-scale.
+// The zero value is 0. Decimals are immutable; every operation returns a new one.
+type Decimal struct {
+	coef  *big.Int // nil means 0
+	scale int
+}
+
+var (
+	ErrSyntax         = errors.New("invalid decimal")
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+type RoundingMode int
+
+const (
+	HalfUp RoundingMode = iota
+	HalfEven
+	Down
+	Up
+	Floor
+	Ceil
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfUp:
+		return "HalfUp"
+	case HalfEven:
+		return "HalfEven"
+	case Down:
+		return "Down"
+	case Up:
+		return "Up"
+	case Floor:
+		return "Floor"
+	case Ceil:
+		return "Ceil"
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int(m))
+}
+
+// New returns coef × 10^-scale: New(12345, 2) is 123.45.
+func New(coef int64, scale int) Decimal {
+	return Decimal{big.NewInt(coef), scale}
+}
+
+// FromInt returns n as a decimal with scale 0.
+func FromInt(n int64) Decimal {
+	return New(n, 0)
+}
+
+// MaxExponent bounds the exponent Parse accepts. Without it a short input
+// like "1e999999999" would make Parse build a billion-digit number.
+const MaxExponent = 1000
+
+// Parse reads "123.45", "-0.5", "+7" or "1.5e3". The scale is the number of
+// digits after the point, so "2.50" keeps its trailing zero.
+func Parse(s string) (Decimal, error) {
+	text := s
+	exp := 0
+	if i := strings.IndexAny(text, "eE"); i >= 0 {
+		e, err := strconv.Atoi(text[i+1:])
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return Decimal{}, fmt.Errorf("%w: %q: bad exponent", ErrSyntax, s)
+		}
+		if err != nil || e > MaxExponent || e < -MaxExponent {
+			return Decimal{}, fmt.Errorf("%w: %q: exponent beyond ±%d", ErrSyntax, s, MaxExponent)
+		}
+		text, exp = text[:i], e
+	}
+
+	intPart, fracPart, _ := strings.Cut(text, ".")
+	digits := intPart + fracPart
+	if strings.HasPrefix(digits, "+") || strings.HasPrefix(digits, "-") {
+		digits = digits[1:]
+	}
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || strings.ContainsAny(fracPart, "+-") {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	coef, _ := new(big.Int).SetString(intPart+fracPart, 10)
+	d := Decimal{coef, len(fracPart) - exp}
+	if d.scale < 0 {
+		// 1.5e3 has scale -2; store it as 1500 with scale 0.
+		d = Decimal{coef.Mul(coef, pow10(-d.scale)), 0}
+	}
+	return d, nil
+}
+
+// MustParse is Parse for constants in code; it panics on bad input.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) bigCoef() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int { return d.scale }
+
+// rescale returns d's coefficient at a larger scale.
+func (d Decimal) rescale(scale int) *big.Int {
+	c := new(big.Int).Set(d.bigCoef())
+	if scale > d.scale {
+		c.Mul(c, pow10(scale-d.scale))
+	}
+	return c
+}
+
+// align brings a and b to the same scale.
+func align(a, b Decimal) (x, y *big.Int, scale int) {
+	scale = max(a.scale, b.scale)
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{x.Add(x, y), scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{x.Sub(x, y), scale}
+}
+
+// Mul is exact: the result's scale is the sum of both scales.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{new(big.Int).Mul(d.bigCoef(), o.bigCoef()), d.scale + o.scale}
+}
+
+// Div returns d / o rounded to scale digits after the point.
+func (d Decimal) Div(o Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if o.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	// d/o = (cd / co) × 10^(so - sd); scale the numerator so the quotient
+	// has scale digits, then round the remainder.
+	num := new(big.Int).Set(d.bigCoef())
+	den := new(big.Int).Set(o.bigCoef())
+	if shift := scale - d.scale + o.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{divRound(num, den, mode), scale}, nil
+}
+
+// Round returns d with exactly scale digits after the point.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{d.rescale(scale), scale}
+	}
+	return Decimal{divRound(d.bigCoef(), pow10(d.scale-scale), mode), scale}
+}
+
+// divRound divides num by den and rounds the quotient with mode.
+func divRound(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// The exact result is negative if exactly one of num and den is.
+	negative := (num.Sign() < 0) != (den.Sign() < 0)
+	// Compare 2|r| with |den| to see which side of the halfway point we are on.
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmpHalf := half.Cmp(new(big.Int).Abs(den))
+
+	away := false // QuoRem truncates toward zero; away means add one unit
+	switch mode {
+	case HalfUp:
+		away = cmpHalf >= 0
+	case HalfEven:
+		away = cmpHalf > 0 || (cmpHalf == 0 && q.Bit(0) == 1)
+	case Down:
+	case Up:
+		away = true
+	case Floor:
+		away = negative
+	case Ceil:
+		away = !negative
+	}
+	if away {
+		if negative {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Cmp returns -1, 0 or +1. 2.5 and 2.50 compare equal.
+func (d Decimal) Cmp(o Decimal) int {
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+func (d Decimal) Equal(o Decimal) bool { return d.Cmp(o) == 0 }
+func (d Decimal) Sign() int            { return d.bigCoef().Sign() }
+func (d Decimal) IsZero() bool         { return d.Sign() == 0 }
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{new(big.Int).Neg(d.bigCoef()), d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{new(big.Int).Abs(d.bigCoef()), d.scale}
+}
+
+// String writes all digits of the scale: New(250, 2) is "2.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.bigCoef()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.scale) // New(5, -2) is 500
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// StringFixed rounds half up to places digits: MustParse("2.345").StringFixed(2) is "2.35".
+func (d Decimal) StringFixed(places int) string {
+	return d.Round(places, HalfUp).String()
+}
+
+// Float64 returns the nearest float64 and whether it is exact.
+func (d Decimal) Float64() (float64, bool) {
+	if d.scale < 0 {
+		r := new(big.Rat).SetInt(d.rescale(0)) // New(5, -2) is 500
+		return r.Float64()
+	}
+	r := new(big.Rat).SetFrac(d.bigCoef(), pow10(d.scale))
+	return r.Float64()
+}
+
+// MarshalJSON writes a JSON number with all digits: 50000.45, not 50000.449999...
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a number (50000.45) or a string ("50000.45").
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC/DECIMAL column. Drivers usually return text for
+// these; float64 columns are converted through their shortest form.
+func (d *Decimal) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case int64:
+		*d = FromInt(v)
+		return nil
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		*d = Decimal{}
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrSyntax, src)
+	}
+	return d.UnmarshalText([]byte(text))
+}
+
+// Value writes the decimal as text, which every SQL driver accepts for NUMERIC.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+type Employee struct {
+	Name   string  `json:"name"`
+	Salary Decimal `json:"salary"`
+}
+
+func main() {
+	x, y := 0.1, 0.2 // variables, so the compiler can't add them exactly
+	fmt.Println("float64: 0.1 + 0.2 =", x+y)
+	fmt.Println("Decimal: 0.1 + 0.2 =", MustParse("0.1").Add(MustParse("0.2")))
+
+	var salary float64 = 50000.45
+	fmt.Println("float64 yearly salary:", salary*12)
+
+	monthly := MustParse("50000.45")
+	total := monthly.Mul(FromInt(12))
+	fmt.Println("Decimal yearly salary:", total)
+
+	// A 3.75% raise, rounded to cents.
+	raise := monthly.Mul(MustParse("0.0375"))
+	fmt.Println("Raise:", raise, "->", raise.StringFixed(2))
+
+	share, _ := total.Div(FromInt(7), 2, HalfEven)
+	fmt.Println("Yearly / 7 =", share)
+	if _, err := total.Div(Decimal{}, 2, HalfUp); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("Rounding to 2 places:")
+	for _, mode := range []RoundingMode{HalfUp, HalfEven, Down, Up, Floor, Ceil} {
+		fmt.Printf("  %-8s", mode)
+		for _, s := range []string{"2.345", "2.355", "-2.345"} {
+			fmt.Printf(" %6s", MustParse(s).Round(2, mode))
+		}
+		fmt.Println()
+	}
+
+	pi := MustParse("3.1405467")
+	fmt.Println("int(pi) truncates:", pi.Round(0, Down), "rounded:", pi.Round(0, HalfUp), "pi to 3 places:", pi.StringFixed(3))
+
+	fmt.Println("2.5 == 2.50:", MustParse("2.5").Equal(MustParse("2.50")), "cmp 1.5e3 vs 1499.99:", MustParse("1.5e3").Cmp(MustParse("1499.99")))
+
+	huge := MustParse("123456789012345678901234567890.123456789")
+	fmt.Println("No size limit:", huge.Mul(huge))
+
+	out, _ := json.Marshal(Employee{"Abhinish", monthly})
+	fmt.Println("JSON:", string(out))
+	var e Employee
+	if err := json.Unmarshal([]byte(`{"name": "Bob", "salary": "61000.10"}`), &e); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("From JSON:", e.Name, e.Salary)
+
+	var scanned Decimal
+	_ = scanned.Scan([]byte("99.990"))
+	value, _ := scanned.Value()
+	fmt.Printf("SQL: scanned %v, value %q\n", scanned, value)
+
+	f, _ := New(5, -2).Float64()
+	fmt.Println("New(5, -2):", New(5, -2), "as float64:", f)
+
+	for _, bad := range []string{"12.3.4", "", "1e", "abc", "1.-5", "1e999999999"} {
+		if _, err := Parse(bad); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string
+		scale int
+	}{
+		{"123.45", "123.45", 2},
+		{"-0.5", "-0.5", 1},
+		{"+7", "7", 0},
+		{"2.50", "2.50", 2},
+		{".5", "0.5", 1},
+		{"5.", "5", 0},
+		{"1.5e3", "1500", 0},
+		{"1.5E-3", "0.0015", 4},
+		{"-12e+2", "-1200", 0},
+		{"1e1000", "1" + strings.Repeat("0", 1000), 0},
+		{"1e-1000", "0." + strings.Repeat("0", 999) + "1", 1000},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		if err != nil || d.String() != tt.want || d.Scale() != tt.scale {
+			t.Errorf("Parse(%q) = %s (scale %d), %v; want %s (scale %d)", tt.in, d, d.Scale(), err, tt.want, tt.scale)
+		}
+	}
+
+	for _, bad := range []string{"", "+", "-", ".", "12.3.4", "1e", "e5", "abc", "1.-5", "1e1001", "1e-1001", "1e999999999999999999999"} {
+		if d, err := Parse(bad); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q) = %s, %v; want ErrSyntax", bad, d, err)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"0.1 + 0.2", MustParse("0.1").Add(MustParse("0.2")), "0.3"},
+		{"1 - 0.001", FromInt(1).Sub(MustParse("0.001")), "0.999"},
+		{"50000.45 × 12", MustParse("50000.45").Mul(FromInt(12)), "600005.40"},
+		{"-1.5 × 1.5", MustParse("-1.5").Mul(MustParse("1.5")), "-2.25"},
+		{"New(5, -2) + 1", New(5, -2).Add(FromInt(1)), "501"},
+		{"zero value + 1.5", Decimal{}.Add(MustParse("1.5")), "1.5"},
+		{"neg", MustParse("2.50").Neg(), "-2.50"},
+		{"abs", MustParse("-2.50").Abs(), "2.50"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDivAndRound(t *testing.T) {
+	rounds := []struct {
+		in   string
+		mode RoundingMode
+		want string
+	}{
+		{"2.345", HalfUp, "2.35"}, {"2.355", HalfUp, "2.36"}, {"-2.345", HalfUp, "-2.35"},
+		{"2.345", HalfEven, "2.34"}, {"2.355", HalfEven, "2.36"}, {"-2.345", HalfEven, "-2.34"},
+		{"2.345", Down, "2.34"}, {"2.355", Down, "2.35"}, {"-2.345", Down, "-2.34"},
+		{"2.345", Up, "2.35"}, {"2.355", Up, "2.36"}, {"-2.345", Up, "-2.35"},
+		{"2.345", Floor, "2.34"}, {"2.355", Floor, "2.35"}, {"-2.345", Floor, "-2.35"},
+		{"2.345", Ceil, "2.35"}, {"2.355", Ceil, "2.36"}, {"-2.345", Ceil, "-2.34"},
+		{"2.3", HalfUp, "2.30"},
+	}
+	for _, tt := range rounds {
+		if got := MustParse(tt.in).Round(2, tt.mode).String(); got != tt.want {
+			t.Errorf("Round(%s, 2, %s) = %s, want %s", tt.in, tt.mode, got, tt.want)
+		}
+	}
+
+	divs := []struct {
+		a, b  string
+		scale int
+		mode  RoundingMode
+		want  string
+	}{
+		{"1", "3", 5, HalfUp, "0.33333"},
+		{"2", "3", 5, HalfUp, "0.66667"},
+		{"2", "3", 5, Down, "0.66666"},
+		{"-2", "3", 2, Floor, "-0.67"},
+		{"1", "8", 2, HalfEven, "0.12"},
+		{"600005.40", "7", 2, HalfEven, "85715.06"},
+		{"1", "0.001", 0, HalfUp, "1000"},
+	}
+	for _, tt := range divs {
+		got, err := MustParse(tt.a).Div(MustParse(tt.b), tt.scale, tt.mode)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s / %s (%d, %s) = %s, %v; want %s", tt.a, tt.b, tt.scale, tt.mode, got, err, tt.want)
+		}
+	}
+	if _, err := FromInt(1).Div(Decimal{}, 2, HalfUp); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("1 / 0: err = %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	tests := []struct {
+		d     Decimal
+		want  float64
+		exact bool
+	}{
+		{New(5, -2), 500, true},
+		{New(-7, -3), -7000, true},
+		{New(12345, 2), 123.45, false},
+		{MustParse("0.5"), 0.5, true},
+		{Decimal{}, 0, true},
+	}
+	for _, tt := range tests {
+		if got, exact := tt.d.Float64(); got != tt.want || exact != tt.exact {
+			t.Errorf("%s.Float64() = %v, %t; want %v, %t", tt.d, got, exact, tt.want, tt.exact)
+		}
+	}
+}
+
+func TestCodecs(t *testing.T) {
+	out, err := json.Marshal(Employee{"Ann", MustParse("50000.45")})
+	if err != nil || string(out) != `{"name":"Ann","salary":50000.45}` {
+		t.Errorf("json.Marshal = %s, %v", out, err)
+	}
+	for _, in := range []string{`{"salary": 61000.10}`, `{"salary": "61000.10"}`} {
+		var e Employee
+		if err := json.Unmarshal([]byte(in), &e); err != nil || e.Salary.String() != "61000.10" {
+			t.Errorf("json.Unmarshal(%s) = %s, %v", in, e.Salary, err)
+		}
+	}
+	var e Employee
+	if err := json.Unmarshal([]byte(`{"salary": 1e999999}`), &e); !errors.Is(err, ErrSyntax) {
+		t.Errorf("json.Unmarshal with a huge exponent: err = %v, want ErrSyntax", err)
+	}
+
+	scans := []struct {
+		src  any
+		want string
+	}{
+		{"99.990", "99.990"},
+		{[]byte("99.990"), "99.990"},
+		{int64(99), "99"},
+		{99.99, "99.99"},
+		{nil, "0"},
+	}
+	for _, tt := range scans {
+		var d Decimal
+		if err := d.Scan(tt.src); err != nil || d.String() != tt.want {
+			t.Errorf("Scan(%#v) = %s, %v; want %s", tt.src, d, err, tt.want)
+		}
+	}
+	var d Decimal
+	if err := d.Scan("1e5000"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Scan(1e5000): err = %v, want ErrSyntax", err)
+	}
+	if err := d.Scan(true); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Scan(true): err = %v, want ErrSyntax", err)
+	}
+}
+
+// TestRoundTrip checks String(Parse(s)) == s for random values.
+func TestRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 10000 {
+		d := New(rng.Int64()>>uint(rng.IntN(63)), rng.IntN(12))
+		if rng.IntN(2) == 0 {
+			d = d.Neg()
+		}
+		back, err := Parse(d.String())
+		if err != nil || back.String() != d.String() || !back.Equal(d) {
+			t.Fatalf("Parse(%q) = %s, %v", d.String(), back, err)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"123.45", "-0.5", "+7", "2.50", "1.5e3", "1e-3", ".5", "5.", "", "1e", "abc", "1.-5", "1e1001"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		d, err := Parse(s)
+		if err != nil {
+			if !errors.Is(err, ErrSyntax) {
+				t.Fatalf("Parse(%q) error %v does not wrap ErrSyntax", s, err)
+			}
+			return
+		}
+		// Whatever Parse accepts, String prints in a form Parse reads back
+		// to the same value and scale.
+		text := d.String()
+		back, err := Parse(text)
+		if err != nil {
+			t.Fatalf("Parse(%q) = %s, but Parse(%q) failed: %v", s, text, text, err)
+		}
+		if !back.Equal(d) || back.Scale() != d.Scale() || back.String() != text {
+			t.Fatalf("Parse(%q) = %s (scale %d), reparsed as %s (scale %d)", s, text, d.Scale(), back, back.Scale())
+		}
+	})
+}
+
+var (
+	benchA, benchB = MustParse("50000.45"), MustParse("1.0375")
+	benchRatA, _   = new(big.Rat).SetString("50000.45")
+	benchRatB, _   = new(big.Rat).SetString("1.0375")
+	benchFloatA    = big.NewFloat(50000.45)
+	benchFloatB    = big.NewFloat(1.0375)
+)
+
+func BenchmarkDecimalAdd(b *testing.B) {
+	for b.Loop() {
+		_ = benchA.Add(benchB)
+	}
+}
+
+func BenchmarkRatAdd(b *testing.B) {
+	for b.Loop() {
+		_ = new(big.Rat).Add(benchRatA, benchRatB)
+	}
+}
+
+func BenchmarkFloatAdd(b *testing.B) {
+	for b.Loop() {
+		_ = new(big.Float).Add(benchFloatA, benchFloatB)
+	}
+}
+
+func BenchmarkDecimalMul(b *testing.B) {
+	for b.Loop() {
+		_ = benchA.Mul(benchB)
+	}
+}
+
+func BenchmarkRatMul(b *testing.B) {
+	for b.Loop() {
+		_ = new(big.Rat).Mul(benchRatA, benchRatB)
+	}
+}
+
+func BenchmarkDecimalDiv(b *testing.B) {
+	for b.Loop() {
+		_, _ = benchA.Div(benchB, 10, HalfEven)
+	}
+}
+
+func BenchmarkRatQuo(b *testing.B) {
+	for b.Loop() {
+		_ = new(big.Rat).Quo(benchRatA, benchRatB)
+	}
+}
+
+func BenchmarkParseString(b *testing.B) {
+	for b.Loop() {
+		_ = MustParse("50000.45").String()
+	}
+}
@@ -0,0 +1,437 @@
+/*
+	Employee Model:
+
+		The variables practice keeps an employee in loose variables:
+
+		var name string = "Abhinish"
+		age := 18
+		var salary float64 = 50000.45
+		var isEmployed bool = true
+
+		Nothing stops age = -5 or name = "". Grouping them in a struct gives one
+		place to check the rules, written as struct tags:
+
+		Rule				Meaning
+		required			not the zero value ("" for strings)
+		min=18, max=65		numbers: value range (NaN and ±Inf always fail);
+							strings: length range
+		oneof=A|B|C			one of the listed values
+		email				looks like user@host.tld
+		omitempty			skip the other rules when the field is empty
+
+		NewEmployee validates on construction. Validate checks any struct and
+		returns every failing field, not just the first.
+
+		Records can be read and written as JSON and CSV. ReadCSV keeps going
+		after a bad row and reports each one with its line number.
+
+		Run: go run main.go employees.csv
+		Test: go test main.go main_test.go
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Employee struct {
+	Name       string  `json:"name" csv:"name" validate:"required,max=50"`
+	Age        int     `json:"age" csv:"age" validate:"min=18,max=65"`
+	Salary     float64 `json:"salary" csv:"salary" validate:"min=0"`
+	IsEmployed bool    `json:"employed" csv:"employed"`
+	Email      string  `json:"email,omitempty" csv:"email" validate:"omitempty,email"`
+	Department string  `json:"department" csv:"department" validate:"required,oneof=Engineering|Sales|Support|Finance"`
+}
+
+// NewEmployee builds a valid employee or explains what is wrong.
+func NewEmployee(name string, age int, salary float64, isEmployed bool, department string) (*Employee, error) {
+	e := &Employee{Name: name, Age: age, Salary: salary, IsEmployed: isEmployed, Department: department}
+	if err := Validate(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// FieldError is one failed rule on one field.
+type FieldError struct {
+	Field string
+	Rule  string
+	Msg   string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Msg
+}
+
+// ValidationErrors collects every failed rule of a struct.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var ErrBadRule = errors.New("bad validation rule")
+
+// Validate checks the `validate` tags of a struct (or pointer to one).
+// It returns ValidationErrors listing every failed field, or nil.
+func Validate(v any) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: Validate needs a struct, got %T", ErrBadRule, v)
+	}
+
+	var errs ValidationErrors
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		name := fieldName(f)
+		value := rv.Field(i)
+
+		for _, rule := range strings.Split(tag, ",") {
+			key, arg, _ := strings.Cut(rule, "=")
+			if key == "omitempty" {
+				if value.IsZero() {
+					break
+				}
+				continue
+			}
+			msg, err := check(value, key, arg)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			if msg != "" {
+				errs = append(errs, FieldError{name, key, msg})
+				break // one message per field is enough
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// fieldName is the json name if there is one, so errors match the input.
+func fieldName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// check runs one rule; it returns a message if the value fails it.
+func check(v reflect.Value, rule, arg string) (string, error) {
+	switch rule {
+	case "required":
+		if v.IsZero() {
+			return "is required", nil
+		}
+	case "min", "max":
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s=%q", ErrBadRule, rule, arg)
+		}
+		n, unit := 0.0, ""
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			// NaN compares false with every limit and +Inf passes any min,
+			// so neither would ever fail a range rule on its own.
+			n = v.Float()
+			if math.IsNaN(n) || math.IsInf(n, 0) {
+				return fmt.Sprintf("must be a finite number (got %v)", n), nil
+			}
+		case reflect.String:
+			// Characters, not bytes: "José" is 4 long.
+			n, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+		case reflect.Slice, reflect.Map:
+			n, unit = float64(v.Len()), " items"
+		default:
+			return "", fmt.Errorf("%w: %s on %s", ErrBadRule, rule, v.Kind())
+		}
+		if rule == "min" && n < limit {
+			if unit != "" {
+				return fmt.Sprintf("must have at least %s%s", arg, unit), nil
+			}
+			return fmt.Sprintf("must be at least %s (got %v)", arg, v.Interface()), nil
+		}
+		if rule == "max" && n > limit {
+			if unit != "" {
+				return fmt.Sprintf("must have at most %s%s", arg, unit), nil
+			}
+			return fmt.Sprintf("must be at most %s (got %v)", arg, v.Interface()), nil
+		}
+	case "oneof":
+		options := strings.Split(arg, "|")
+		s := fmt.Sprint(v.Interface())
+		for _, o := range options {
+			if s == o {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("must be one of %s (got %q)", strings.Join(options, ", "), s), nil
+	case "email":
+		if v.Kind() != reflect.String {
+			return "", fmt.Errorf("%w: email on %s", ErrBadRule, v.Kind())
+		}
+		user, host, ok := strings.Cut(v.String(), "@")
+		if !ok || user == "" || !strings.Contains(host, ".") || strings.HasSuffix(host, ".") {
+			return fmt.Sprintf("%q is not an email address", v.String()), nil
+		}
+	default:
+		return "", fmt.Errorf("%w: unknown rule %q", ErrBadRule, rule)
+	}
+	return "", nil
+}
+
+// RowError is a CSV row that could not be parsed or validated.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error { return e.Err }
+
+// ReadCSV reads employees with a header row. Valid rows are returned even
+// when others fail; every bad row is reported as a RowError, joined together.
+func ReadCSV(r io.Reader) ([]Employee, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // report short rows ourselves, with a line number
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	var employees []Employee
+	var errs []error
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// A broken row (e.g. an unclosed quote): csv.ParseError has the line.
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				errs = append(errs, RowError{perr.StartLine, perr.Err})
+				continue
+			}
+			// The reader itself failed: nothing more can be read, but the
+			// rows reported so far still are.
+			return employees, errors.Join(append(errs, err)...)
+		}
+		line, _ := reader.FieldPos(0)
+
+		var e Employee
+		if err := decodeRow(&e, columns, record); err != nil {
+			errs = append(errs, RowError{line, err})
+			continue
+		}
+		if err := Validate(&e); err != nil {
+			errs = append(errs, RowError{line, err})
+			continue
+		}
+		employees = append(employees, e)
+	}
+	return employees, errors.Join(errs...)
+}
+
+// decodeRow sets each field from the column named by its `csv` tag.
+func decodeRow(dst any, columns map[string]int, record []string) error {
+	rv := reflect.ValueOf(dst).Elem()
+	t := rv.Type()
+	var errs ValidationErrors
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		col, ok := columns[f.Tag.Get("csv")]
+		if !ok || col >= len(record) {
+			continue // missing column: leave the zero value for Validate to judge
+		}
+		text := strings.TrimSpace(record[col])
+		if text == "" {
+			continue
+		}
+
+		field := rv.Field(i)
+		var err error
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(text)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var n int64
+			if n, err = strconv.ParseInt(text, 10, 64); err == nil {
+				field.SetInt(n)
+			}
+		case reflect.Float32, reflect.Float64:
+			var x float64
+			if x, err = strconv.ParseFloat(text, 64); err == nil {
+				field.SetFloat(x)
+			}
+		case reflect.Bool:
+			var b bool
+			if b, err = strconv.ParseBool(text); err == nil {
+				field.SetBool(b)
+			}
+		}
+		if err != nil {
+			errs = append(errs, FieldError{fieldName(f), "type", fmt.Sprintf("%q is not a valid %s", text, field.Kind())})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// WriteCSV writes a header row and one row per employee.
+func WriteCSV(w io.Writer, employees []Employee) error {
+	writer := csv.NewWriter(w)
+	t := reflect.TypeFor[Employee]()
+
+	header := make([]string, t.NumField())
+	for i := range header {
+		header[i] = t.Field(i).Tag.Get("csv")
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, e := range employees {
+		rv := reflect.ValueOf(e)
+		row := make([]string, t.NumField())
+		for i := range row {
+			switch f := rv.Field(i); f.Kind() {
+			case reflect.Float64:
+				row[i] = strconv.FormatFloat(f.Float(), 'f', -1, 64)
+			default:
+				row[i] = fmt.Sprint(f.Interface())
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadJSON reads a JSON array of employees and validates each one.
+// Errors name the array index, since JSON has no useful line numbers here.
+func ReadJSON(r io.Reader) ([]Employee, error) {
+	var all []Employee
+	if err := json.NewDecoder(r).Decode(&all); err != nil {
+		return nil, err
+	}
+	var valid []Employee
+	var errs []error
+	for i, e := range all {
+		if err := Validate(&e); err != nil {
+			errs = append(errs, fmt.Errorf("employee %d: %w", i, err))
+			continue
+		}
+		valid = append(valid, e)
+	}
+	return valid, errors.Join(errs...)
+}
+
+// WriteJSON writes employees as an indented JSON array.
+func WriteJSON(w io.Writer, employees []Employee) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(employees)
+}
+
+func main() {
+
+	e, err := NewEmployee("Abhinish", 18, 50000.45, true, "Engineering")
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Printf("Employee: %+v\n", *e)
+	}
+
+	if _, err := NewEmployee("", -5, -100, true, "Space"); err != nil {
+		fmt.Println("Error:", err)
+		var verrs ValidationErrors
+		if errors.As(err, &verrs) {
+			for _, fe := range verrs {
+				fmt.Printf("  field=%s rule=%s\n", fe.Field, fe.Rule)
+			}
+		}
+	}
+
+	if _, err := NewEmployee("Nan", 30, math.NaN(), true, "Sales"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	path := "employees.csv"
+	if len(os.Args) > 1 {
+		path = os.Args[1]
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer file.Close()
+
+	employees, err := ReadCSV(file)
+	if err != nil {
+		// ReadCSV joins one RowError per bad row, but a failed header read
+		// comes back as a single plain error.
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			fmt.Println("Invalid rows in", path+":")
+			for _, rowErr := range joined.Unwrap() {
+				fmt.Println(" ", rowErr)
+			}
+		} else {
+			fmt.Println("Error:", err)
+		}
+	}
+	fmt.Println("Valid employees:", len(employees))
+
+	fmt.Println("As CSV:")
+	if err := WriteCSV(os.Stdout, employees); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("As JSON:")
+	if err := WriteJSON(os.Stdout, employees[:min(1, len(employees))]); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	_, err = ReadJSON(strings.NewReader(`[{"name": "Zed", "age": 70, "salary": 1, "department": "Sales", "email": "zed"}]`))
+	fmt.Println("Error:", err)
+}
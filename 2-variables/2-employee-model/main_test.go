@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func valid() Employee {
+	return Employee{Name: "Abhinish", Age: 24, Salary: 50000.45, IsEmployed: true, Email: "a@example.com", Department: "Engineering"}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*Employee)
+		want   map[string]string // field -> rule
+	}{
+		{"valid", func(*Employee) {}, nil},
+		{"no email", func(e *Employee) { e.Email = "" }, nil},
+		{"50 accented letters", func(e *Employee) { e.Name = strings.Repeat("é", 50) }, nil},
+		{"51 letters", func(e *Employee) { e.Name = strings.Repeat("é", 51) }, map[string]string{"name": "max"}},
+		{"everything wrong", func(e *Employee) { *e = Employee{Age: -5, Salary: -1, Department: "Space"} },
+			map[string]string{"name": "required", "age": "min", "salary": "min", "department": "oneof"}},
+		{"too old", func(e *Employee) { e.Age = 66 }, map[string]string{"age": "max"}},
+		{"NaN salary", func(e *Employee) { e.Salary = math.NaN() }, map[string]string{"salary": "min"}},
+		{"infinite salary", func(e *Employee) { e.Salary = math.Inf(1) }, map[string]string{"salary": "min"}},
+		{"bad email", func(e *Employee) { e.Email = "a@example." }, map[string]string{"email": "email"}},
+	}
+	for _, tt := range tests {
+		e := valid()
+		tt.change(&e)
+		err := Validate(&e)
+		got := map[string]string{}
+		var verrs ValidationErrors
+		if errors.As(err, &verrs) {
+			for _, fe := range verrs {
+				got[fe.Field] = fe.Rule
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if len(got) != len(tt.want) || (len(tt.want) > 0 && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("%s: failed rules %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBadRule(t *testing.T) {
+	tests := []any{
+		struct {
+			N int `validate:"email"`
+		}{},
+		struct {
+			N int `validate:"min=ten"`
+		}{},
+		struct {
+			B bool `validate:"max=1"`
+		}{},
+		struct {
+			S string `validate:"positive"`
+		}{},
+		42,
+	}
+	for _, v := range tests {
+		if err := Validate(v); !errors.Is(err, ErrBadRule) {
+			t.Errorf("Validate(%#v) = %v, want ErrBadRule", v, err)
+		}
+	}
+}
+
+func TestReadCSVLines(t *testing.T) {
+	input := `name,age,salary,employed,email,department
+Ann,30,100,true,,Sales
+Bob,16,100,true,,Sales
+"Multi
+line",30,100,true,,Sales
+Cy,x"y,100,true,,Sales
+Di,30,100,maybe,,Sales
+Ed,30,100,true,,Support
+`
+	employees, err := ReadCSV(strings.NewReader(input))
+
+	var names []string
+	for _, e := range employees {
+		names = append(names, e.Name)
+	}
+	if want := []string{"Ann", "Multi\nline", "Ed"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("valid rows %q, want %q", names, want)
+	}
+	if got, want := rowLines(err), []int{3, 6, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bad row lines %v, want %v (err: %v)", got, want, err)
+	}
+}
+
+// rowLines returns the Line of every RowError joined in err.
+func rowLines(err error) []int {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	var lines []int
+	for _, e := range joined.Unwrap() {
+		var re RowError
+		if errors.As(e, &re) {
+			lines = append(lines, re.Line)
+		}
+	}
+	return lines
+}
+
+var errDisk = errors.New("disk gone")
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errDisk }
+
+// A read error ends the file but keeps the rows already reported.
+func TestReadCSVReadError(t *testing.T) {
+	input := io.MultiReader(strings.NewReader("name,age,salary,employed,email,department\nAnn,30,100,true,,Sales\n,30,100,true,,Sales\n"), failingReader{})
+	employees, err := ReadCSV(input)
+	if len(employees) != 1 || !errors.Is(err, errDisk) {
+		t.Fatalf("ReadCSV = %d employees, %v; want 1 and errDisk", len(employees), err)
+	}
+	if got := rowLines(err); !reflect.DeepEqual(got, []int{3}) {
+		t.Errorf("row errors on lines %v, want [3] next to the read error", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := []Employee{
+		valid(),
+		{Name: "Zoë, \"Z\"", Age: 65, Salary: 0.1, Department: "Finance"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadCSV(&buf)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("CSV round trip = %+v, %v; want %+v", got, err, want)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadJSON(&buf)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON round trip = %+v, %v; want %+v", got, err, want)
+	}
+}
@@ -0,0 +1,521 @@
+/*
+	Zero Values and Defaults:
+
+		Every Go variable starts at its zero value:
+
+		string ""	int 0	float64 0	bool false	pointer/slice/map nil
+
+		For config structs that creates a problem: is Port 0 because nobody set
+		it, or because someone asked for port 0? Two tools help:
+
+		1. default tags fill fields that are still zero:
+
+			type Server struct {
+				Host    string        `default:"localhost"`
+				Port    int           `default:"8080"`
+				Timeout time.Duration `default:"30s"`
+				Tags    []string      `default:"web,api"`
+				Limits  map[string]int `default:"read:100,write:10"`
+			}
+
+		   SetDefaults walks nested structs through pointers, slices, arrays
+		   and maps (map[string]*Worker too). ZeroFields walks the same way and
+		   lists the fields that are still zero afterwards (e.g.
+		   "Database.Password"), which is handy for "missing config" errors.
+
+		2. Opt[T] records whether a value was set at all:
+
+			Opt[int]{}		unset		JSON: field left out (with omitzero) or null
+			Some(0)			set to 0	JSON: 0
+
+		   A default tag only fills an Opt that is unset, so an explicit 0 or
+		   false survives.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Opt is a value that is either set (even to its zero value) or unset.
+// The zero Opt is unset.
+type Opt[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns a set Opt.
+func Some[T any](v T) Opt[T] {
+	return Opt[T]{value: v, set: true}
+}
+
+// Get returns the value and whether it was set.
+func (o Opt[T]) Get() (T, bool) { return o.value, o.set }
+
+// IsSet reports whether a value was set, even a zero one.
+func (o Opt[T]) IsSet() bool { return o.set }
+
+// OrElse returns the value, or fallback when unset.
+func (o Opt[T]) OrElse(fallback T) T {
+	if o.set {
+		return o.value
+	}
+	return fallback
+}
+
+func (o Opt[T]) String() string {
+	if !o.set {
+		return "<unset>"
+	}
+	return fmt.Sprint(o.value)
+}
+
+// IsZero makes the json "omitzero" option leave out unset fields.
+func (o Opt[T]) IsZero() bool { return !o.set }
+
+// MarshalJSON writes the value, or null when unset.
+func (o Opt[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON sets the Opt from any value; null leaves it unset.
+func (o *Opt[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Opt[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.value); err != nil {
+		return err
+	}
+	o.set = true
+	return nil
+}
+
+// optional lets the reflection code below work with any Opt[T].
+type optional interface {
+	IsSet() bool
+	valuePtr() any // pointer to the inner value, for filling from a tag
+	markSet()
+}
+
+func (o *Opt[T]) valuePtr() any { return &o.value }
+func (o *Opt[T]) markSet()      { o.set = true }
+
+// asOptional returns v as an Opt, looking through a non-nil pointer.
+// A nil *Opt[T] is not one yet: it is an ordinary nil pointer.
+func asOptional(v reflect.Value) (optional, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if !v.CanAddr() {
+		// ZeroFields may be handed a struct by value; copy it so the
+		// pointer methods are reachable.
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+	opt, ok := v.Addr().Interface().(optional)
+	return opt, ok
+}
+
+var ErrNotPointer = errors.New("SetDefaults needs a pointer to a struct")
+
+// DefaultError reports a default tag that doesn't fit its field.
+type DefaultError struct {
+	Field string
+	Tag   string
+	Err   error
+}
+
+func (e *DefaultError) Error() string {
+	return fmt.Sprintf("field %s: default %q: %v", e.Field, e.Tag, e.Err)
+}
+
+func (e *DefaultError) Unwrap() error { return e.Err }
+
+// SetDefaults fills zero fields of the struct v points to from their
+// `default` tags. It reports every bad tag, not just the first.
+func SetDefaults(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w, got %T", ErrNotPointer, v)
+	}
+	var errs []error
+	fillStruct(rv.Elem(), "", &errs)
+	return errors.Join(errs...)
+}
+
+func fillStruct(v reflect.Value, path string, errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		field := v.Field(i)
+		fieldPath := joinPath(path, f.Name)
+		tag, hasTag := f.Tag.Lookup("default")
+
+		if opt, ok := asOptional(field); ok {
+			if hasTag && !opt.IsSet() {
+				if err := setFromString(field, tag); err != nil {
+					*errs = append(*errs, &DefaultError{fieldPath, tag, err})
+				}
+			}
+			continue
+		}
+
+		if hasTag && field.IsZero() {
+			if err := setFromString(field, tag); err != nil {
+				*errs = append(*errs, &DefaultError{fieldPath, tag, err})
+			}
+		}
+		fillNested(field, fieldPath, errs)
+	}
+}
+
+// fillNested descends into structs held directly, by pointer, or in slices,
+// arrays and maps, in any combination ([]*Worker, map[string]*Worker).
+func fillNested(v reflect.Value, path string, errs *[]error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() != timeType {
+			fillStruct(v, path, errs)
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			fillNested(v.Elem(), path, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		if !holdsStructs(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			fillNested(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.Map:
+		if !holdsStructs(v.Type().Elem()) {
+			return
+		}
+		// Map values can't be changed in place: copy, fill, store back.
+		for _, key := range sortedKeys(v) {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			fillNested(elem, fmt.Sprintf("%s[%v]", path, key), errs)
+			v.SetMapIndex(key, elem)
+		}
+	}
+}
+
+// holdsStructs reports whether values of type t can contain structs for the
+// walkers to descend into.
+func holdsStructs(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsStructs(t.Elem())
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in a fixed order, so paths in errors
+// and in ZeroFields don't change from run to run.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return keys
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// setFromString parses text into v according to v's type.
+//
+//	slices: "a,b,c"		maps: "k:v,k2:v2"		pointers: value for the target
+func setFromString(v reflect.Value, text string) error {
+	if opt, ok := asOptional(v); ok {
+		if err := setFromString(reflect.ValueOf(opt.valuePtr()).Elem(), text); err != nil {
+			return err
+		}
+		opt.markSet()
+		return nil
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if v.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := setFromString(elem.Elem(), text); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice:
+		parts := strings.Split(text, ",")
+		s := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setFromString(s.Index(i), strings.TrimSpace(p)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for _, pair := range strings.Split(text, ",") {
+			k, val, ok := strings.Cut(pair, ":")
+			if !ok {
+				return fmt.Errorf("map entry %q needs key:value", pair)
+			}
+			key := reflect.New(v.Type().Key()).Elem()
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setFromString(key, strings.TrimSpace(k)); err != nil {
+				return err
+			}
+			if err := setFromString(elem, strings.TrimSpace(val)); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("cannot set a default for %s", v.Type())
+	}
+	return nil
+}
+
+// ZeroFields lists the exported fields of v, a struct or a pointer to one,
+// that hold their zero value, as dotted paths. An Opt counts as zero only
+// when unset. Anything else, including a nil pointer, gives nil.
+func ZeroFields(v any) []string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	rv = reflect.Indirect(rv)
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var out []string
+	collectZero(rv, "", &out)
+	return out
+}
+
+func collectZero(v reflect.Value, path string, out *[]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		collectZeroValue(v.Field(i), joinPath(path, f.Name), out)
+	}
+}
+
+// collectZeroValue mirrors fillNested: it descends through the same
+// pointers, slices, arrays and maps, and reports whatever is zero.
+func collectZeroValue(v reflect.Value, path string, out *[]string) {
+	if opt, ok := asOptional(v); ok {
+		if !opt.IsSet() {
+			*out = append(*out, path)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() != timeType {
+			collectZero(v, path, out)
+			return
+		}
+	case reflect.Pointer:
+		if !v.IsNil() && holdsStructs(v.Type().Elem()) {
+			collectZeroValue(v.Elem(), path, out)
+			return
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Len() > 0 && holdsStructs(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				collectZeroValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), out)
+			}
+			return
+		}
+	case reflect.Map:
+		if v.Len() > 0 && holdsStructs(v.Type().Elem()) {
+			for _, key := range sortedKeys(v) {
+				collectZeroValue(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key), out)
+			}
+			return
+		}
+	}
+	if v.IsZero() {
+		*out = append(*out, path)
+	}
+}
+
+type TLS struct {
+	Enabled  Opt[bool] `json:"enabled,omitzero" default:"true"`
+	CertFile string    `json:"cert_file"`
+}
+
+type Database struct {
+	URL      string        `json:"url" default:"postgres://localhost:5432/app"`
+	Password string        `json:"password"`
+	PoolSize int           `json:"pool_size" default:"10"`
+	Timeout  time.Duration `json:"timeout" default:"5s"`
+}
+
+type Worker struct {
+	Name        string `json:"name"`
+	Concurrency int    `json:"concurrency" default:"4"`
+}
+
+type Config struct {
+	Host     string             `json:"host" default:"localhost"`
+	Port     int                `json:"port" default:"8080"`
+	Debug    bool               `json:"debug"`
+	Retries  Opt[int]           `json:"retries,omitzero" default:"3"`
+	Backoff  *Opt[int]          `json:"backoff" default:"250"`
+	MaxIdle  *Opt[int]          `json:"max_idle"`
+	Ratio    *float64           `json:"ratio" default:"0.75"`
+	Tags     []string           `json:"tags" default:"web,api"`
+	Limits   map[string]int     `json:"limits" default:"read:100,write:10"`
+	TLS      TLS                `json:"tls"`
+	Database *Database          `json:"database"`
+	Workers  []Worker           `json:"workers"`
+	Queues   map[string]Worker  `json:"queues"`
+	Standby  map[string]*Worker `json:"standby"`
+}
+
+func main() {
+
+	var str string
+	var num int
+	var decimal float64
+	var isTrue bool
+	fmt.Printf("Zero values: %q %d %v %t\n", str, num, decimal, isTrue)
+
+	// Retries is explicitly 0 and TLS is explicitly off: defaults must not override them.
+	input := `{
+		"port": 9090,
+		"retries": 0,
+		"tls": {"enabled": false},
+		"database": {"password": "s3cret"},
+		"workers": [{"name": "mailer"}, {"name": "resizer", "concurrency": 16}],
+		"queues": {"high": {"name": "urgent"}},
+		"standby": {"b": {"name": "backup"}, "a": {"name": "archive", "concurrency": 1}}
+	}`
+	var cfg Config
+	if err := json.Unmarshal([]byte(input), &cfg); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Zero before defaults:", ZeroFields(&cfg))
+	if err := SetDefaults(&cfg); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("Zero after defaults: ", ZeroFields(&cfg))
+
+	fmt.Println("Host:", cfg.Host, "Port:", cfg.Port, "Ratio:", *cfg.Ratio, "Tags:", cfg.Tags, "Limits:", cfg.Limits)
+	fmt.Println("Retries:", cfg.Retries, "(explicit 0 kept), TLS enabled:", cfg.TLS.Enabled)
+	fmt.Printf("Database: %+v\n", *cfg.Database)
+	fmt.Printf("Workers: %+v, Queues: %+v, Standby b: %+v\n", cfg.Workers, cfg.Queues, *cfg.Standby["b"])
+	fmt.Println("Backoff:", cfg.Backoff, "(nil *Opt filled from its tag), MaxIdle:", cfg.MaxIdle)
+
+	// An empty config gets every default; unset Opts are filled too.
+	var empty Config
+	_ = SetDefaults(&empty)
+	fmt.Println("Empty config -> Retries:", empty.Retries, "TLS:", empty.TLS.Enabled, "Database:", empty.Database)
+
+	// Opt round-trips through JSON: unset is left out, set-to-zero is kept.
+	out, _ := json.Marshal(struct {
+		A Opt[int]    `json:"a,omitzero"`
+		B Opt[int]    `json:"b,omitzero"`
+		C Opt[string] `json:"c"`
+	}{B: Some(0)})
+	fmt.Println("Opt JSON:", string(out))
+
+	var back struct {
+		A, B Opt[int]
+	}
+	_ = json.Unmarshal([]byte(`{"B": 0}`), &back)
+	fmt.Println("Back: A set?", back.A.IsSet(), "B set?", back.B.IsSet(), "A or 42:", back.A.OrElse(42))
+
+	var bad struct {
+		Port  int      `default:"eighty"`
+		Sizes []uint8  `default:"1,2,300"`
+		Opt   Opt[int] `default:"x"`
+	}
+	if err := SetDefaults(&bad); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if err := SetDefaults(cfg); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
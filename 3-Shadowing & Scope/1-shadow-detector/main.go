@@ -0,0 +1,209 @@
+/*
+	Shadow Detector:
+
+		The shadowing lesson declares message := "..." inside main, which
+		silently hides the global message. With err it causes real bugs:
+
+		var err error
+		if cond {
+			result, err := compute() // new err, only inside the if
+			...
+		}
+		return err // still nil
+
+		Analyzer is a golang.org/x/tools/go/analysis pass that reports every
+		variable hiding a name from an outer scope:
+
+		main.go:20:2: message shadows package-level var message (main.go:14:5)
+
+		Flag				Effect
+		-ignore-err			don't report variables named err
+		-ignore-same-type	only report shadows that change the type
+		-allow=ok,tmp*		names (path.Match patterns) never reported
+		-builtins			also report hiding builtins like len or string
+
+		This folder is its own module (go.mod requires x/tools), so build the
+		tool here and point it at files or packages, directly or through go vet:
+
+			go build -o /tmp/shadow .
+			/tmp/shadow ../main.go							one file
+			/tmp/shadow -allow=t ./...						this module (t.Run shadows t)
+			cd .. && go vet -vettool=/tmp/shadow main.go
+
+		(go vet can't run a tool whose path has spaces, as this folder's does.)
+
+		Exit status is non-zero when something was reported, like go vet.
+
+		testdata/src holds analysistest fixtures taken from the scope lessons,
+		one package per flag plus functypes; each expected report is marked
+		with a // want "regexp" comment. Run them with:
+
+			go test .
+*/
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Config selects what gets reported. Analyzer's flags write into it.
+type Config struct {
+	IgnoreErr      bool
+	IgnoreSameType bool
+	Allow          []string // path.Match patterns for names never reported
+	Builtins       bool     // report shadowing of predeclared names (len, string, ...)
+}
+
+// allowList is a flag.Value for comma-separated patterns.
+type allowList struct{ patterns *[]string }
+
+func (a allowList) String() string {
+	if a.patterns == nil {
+		return ""
+	}
+	return strings.Join(*a.patterns, ",")
+}
+
+func (a allowList) Set(s string) error {
+	*a.patterns = nil
+	for p := range strings.SplitSeq(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			*a.patterns = append(*a.patterns, p)
+		}
+	}
+	return nil
+}
+
+var config Config
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "shadow",
+	Doc:      "report variables that shadow a name from an outer scope\n\nA shadowed err is the classic case: result, err := f() inside an if\nleaves the outer err untouched.",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func init() {
+	Analyzer.Flags.BoolVar(&config.IgnoreErr, "ignore-err", false, "don't report variables named err")
+	Analyzer.Flags.BoolVar(&config.IgnoreSameType, "ignore-same-type", false, "only report shadows that change the type")
+	Analyzer.Flags.BoolVar(&config.Builtins, "builtins", false, "report shadowing of predeclared names")
+	Analyzer.Flags.Var(allowList{&config.Allow}, "allow", "comma-separated name patterns never reported")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Parameter names in a function type or an interface method
+	// (type Handler func(message string)) are documentation: nothing can
+	// use them, so they hide nothing. Only the signatures of declared
+	// functions and function literals have a body the names can matter in.
+	bodies := map[*ast.FuncType]bool{}
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			bodies[n.Type] = true
+		case *ast.FuncLit:
+			bodies[n.Type] = true
+		}
+	})
+	signatureOnly := map[*types.Scope]bool{}
+	for node, scope := range pass.TypesInfo.Scopes {
+		if ft, ok := node.(*ast.FuncType); ok && !bodies[ft] {
+			signatureOnly[scope] = true
+		}
+	}
+
+	insp.Preorder([]ast.Node{(*ast.Ident)(nil)}, func(n ast.Node) {
+		id := n.(*ast.Ident)
+		v, ok := pass.TypesInfo.Defs[id].(*types.Var)
+		if !ok || v.IsField() || v.Name() == "_" || v.Parent() == nil || v.Parent() == pass.Pkg.Scope() {
+			return
+		}
+		if signatureOnly[v.Parent()] {
+			return
+		}
+		if config.IgnoreErr && v.Name() == "err" {
+			return
+		}
+		if allowed(v.Name(), config.Allow) {
+			return
+		}
+
+		// Look outward from the scope that holds v, as of v's declaration.
+		outerScope, outer := v.Parent().Parent().LookupParent(v.Name(), v.Pos())
+		if outer == nil {
+			return
+		}
+		if outerScope == types.Universe && !config.Builtins {
+			return
+		}
+		if config.IgnoreSameType && types.Identical(outer.Type(), v.Type()) {
+			return
+		}
+		pass.Reportf(id.Pos(), "%s", describe(pass, v, outer, outerScope))
+	})
+	return nil, nil
+}
+
+func allowed(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// describe builds "x shadows package-level var x (file.go:3:5)" and notes a
+// type change, which is the case most likely to be a bug.
+func describe(pass *analysis.Pass, v *types.Var, outer types.Object, outerScope *types.Scope) string {
+	where := "outer"
+	switch outerScope {
+	case types.Universe:
+		where = "predeclared"
+	case pass.Pkg.Scope():
+		where = "package-level"
+	}
+	if outerScope.Parent() == pass.Pkg.Scope() && outerScope != pass.Pkg.Scope() {
+		where = "imported" // file scope holds imports
+	}
+
+	kind := "var"
+	switch outer.(type) {
+	case *types.Const:
+		kind = "const"
+	case *types.Func:
+		kind = "func"
+	case *types.TypeName:
+		kind = "type"
+	case *types.PkgName:
+		kind = "package"
+	case *types.Builtin:
+		kind = "func"
+	}
+
+	msg := fmt.Sprintf("%s shadows %s %s %s", v.Name(), where, kind, outer.Name())
+	if outer.Pos().IsValid() {
+		p := pass.Fset.Position(outer.Pos())
+		msg += fmt.Sprintf(" (%s:%d:%d)", filepath.Base(p.Filename), p.Line, p.Column)
+	}
+	if _, isVar := outer.(*types.Var); isVar && !types.Identical(outer.Type(), v.Type()) {
+		msg += fmt.Sprintf(", changing type %s -> %s", outer.Type(), v.Type())
+	}
+	return msg
+}
+
+func main() {
+	singlechecker.Main(Analyzer)
+}
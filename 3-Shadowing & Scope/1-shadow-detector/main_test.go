@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// setFlags sets Analyzer flags for one test and restores the defaults after.
+func setFlags(t *testing.T, flags map[string]string) {
+	t.Helper()
+	for name, value := range flags {
+		if err := Analyzer.Flags.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+			f.Value.Set(f.DefValue)
+		})
+	})
+}
+
+func TestAnalyzer(t *testing.T) {
+	tests := []struct {
+		pkg   string
+		flags map[string]string
+	}{
+		{"shadowing", nil},
+		{"scope", nil},
+		{"defaults", nil},
+		{"ignoreerr", map[string]string{"ignore-err": "true"}},
+		{"ignoresametype", map[string]string{"ignore-same-type": "true"}},
+		{"functypes", nil},
+		{"allow", map[string]string{"allow": "ok, tmp*"}},
+		{"builtins", map[string]string{"builtins": "true"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			setFlags(t, tt.flags)
+			analysistest.Run(t, analysistest.TestData(), Analyzer, tt.pkg)
+		})
+	}
+}
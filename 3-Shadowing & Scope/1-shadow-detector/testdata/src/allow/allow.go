@@ -0,0 +1,23 @@
+// Fixture for -allow=ok,tmp*: names matching a pattern are never reported.
+package main
+
+import "fmt"
+
+var (
+	ok      = true
+	tmpFile = "/tmp/a"
+	tmp     = 1
+	total   = 0
+)
+
+func main() {
+	m := map[string]int{"a": 1}
+	if _, ok := m["a"]; ok { // allowed by "ok"
+		fmt.Println("found")
+	}
+
+	tmpFile := "/tmp/b" // allowed by "tmp*"
+	tmp := "x"          // "tmp*" matches tmp too
+	total := 2          // want "total shadows package-level var total"
+	fmt.Println(ok, tmpFile, tmp, total)
+}
@@ -0,0 +1,20 @@
+// Fixture for -builtins: hiding predeclared names is reported too.
+package main
+
+import "fmt"
+
+func main() {
+	len := 3         // want "len shadows predeclared func len"
+	string := "text" // want "string shadows predeclared type string"
+	true := false    // want "true shadows predeclared const true"
+	new := func() {} // want "new shadows predeclared func new"
+	fmt.Println(len, string, true)
+	new()
+
+	copy := "a" // want "copy shadows predeclared func copy"
+	{
+		copy := 1 // want "copy shadows outer var copy .*, changing type string -> int"
+		fmt.Println(copy)
+	}
+	fmt.Println(copy)
+}
@@ -0,0 +1,31 @@
+// Fixture for the production bug: an inner err hides the one that is returned.
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var limit int = 10
+
+func parse(values []string) (total int, err error) {
+	for _, v := range values {
+		n, err := strconv.Atoi(v) // want "err shadows outer var err"
+		if err != nil {
+			break
+		}
+		total += n
+	}
+	return total, err
+}
+
+func describe(fmt string) string { // want "fmt shadows imported package fmt"
+	limit := "none" // want "limit shadows package-level var limit .*, changing type int -> string"
+	len := 3        // predeclared names are only reported with -builtins
+	return fmt + limit + strconv.Itoa(len)
+}
+
+func main() {
+	total, err := parse([]string{"1", "x"})
+	fmt.Println(total, err, describe("n"))
+}
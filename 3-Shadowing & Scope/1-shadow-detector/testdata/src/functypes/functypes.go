@@ -0,0 +1,33 @@
+// Fixture for parameter names in function types: only signatures with a body
+// can shadow anything.
+package main
+
+import "fmt"
+
+var message = "global"
+
+type Handler func(message string) // no body: not reported
+
+type Writer interface {
+	Write(message string) error // interface method: not reported
+}
+
+type printer struct{}
+
+func (printer) Write(message string) error { // want "message shadows package-level var message"
+	fmt.Println(message)
+	return nil
+}
+
+func main() {
+	var cb func(message int) // a variable's function type: not reported
+	cb = func(message int) { // want "message shadows package-level var message"
+		fmt.Println(message)
+	}
+	cb(1)
+
+	var h Handler = func(string) {}
+	var w Writer = printer{}
+	h(message)
+	w.Write(message)
+}
@@ -0,0 +1,31 @@
+// Fixture for -ignore-err: the same code as defaults/errors.go, but an
+// inner err is no longer reported. Other names still are.
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+var limit int = 10
+
+func parse(values []string) (total int, err error) {
+	for _, v := range values {
+		n, err := strconv.Atoi(v) // ignored: named err
+		if err != nil {
+			break
+		}
+		total += n
+	}
+	return total, err
+}
+
+func describe(fmt string) string { // want "fmt shadows imported package fmt"
+	limit := "none" // want "limit shadows package-level var limit .*, changing type int -> string"
+	return fmt + limit
+}
+
+func main() {
+	total, err := parse([]string{"1", "x"})
+	fmt.Println(total, err, describe("n"))
+}
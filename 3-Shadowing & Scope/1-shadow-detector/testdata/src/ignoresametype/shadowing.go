@@ -0,0 +1,26 @@
+// Fixture for -ignore-same-type: re-declaring a name with the same type is
+// allowed; only shadows that change the type are reported.
+package main
+
+import "fmt"
+
+var message = "I am a global variable."
+
+var count = 3
+
+func main() {
+	message := "I am a local variable." // same type (string): not reported
+	fmt.Println(message)
+
+	{
+		message := len(message) // want "message shadows outer var message .*, changing type string -> int"
+		fmt.Println(message)
+	}
+
+	count := "three" // want "count shadows package-level var count .*, changing type int -> string"
+	fmt.Println(count)
+
+	for count := 0; count < 2; count++ { // want "count shadows outer var count .*, changing type string -> int"
+		fmt.Println(count)
+	}
+}
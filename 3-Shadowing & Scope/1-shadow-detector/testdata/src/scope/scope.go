@@ -0,0 +1,18 @@
+// Fixture from the scope lesson (4-Scope/main.go): nothing is shadowed.
+package main
+
+import "fmt"
+
+var globalVar = "Accessible everywhere"
+
+func main() {
+	localVar := "Accessible only in main()"
+
+	{
+		bloclVar := "Accessible only inside this block"
+		fmt.Println(bloclVar)
+	}
+
+	fmt.Println(localVar)
+	fmt.Println(globalVar)
+}
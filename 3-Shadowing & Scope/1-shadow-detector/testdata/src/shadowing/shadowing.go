@@ -0,0 +1,21 @@
+// Fixture from the shadowing lesson (3-Shadowing & Scope/main.go).
+package main
+
+import "fmt"
+
+var message = "I am a global variable."
+
+func main() {
+
+	fmt.Println(message)
+
+	message := "I am a local variable." // want "message shadows package-level var message"
+	fmt.Println(message)
+
+	{
+		message := "I am inside a block" // want "message shadows outer var message"
+		fmt.Println(message)
+	}
+
+	fmt.Println(message)
+}
@@ -0,0 +1,360 @@
+/*
+	Scope Visualizer:
+
+		The scope lesson explains global, function and block scope in
+		comments. This tool shows the real scope tree the compiler builds:
+
+		package main
+		  var globalVar string          line 13
+		  func main func()              line 15
+		  file main.go
+		    package fmt                 line 3
+		    func main (lines 15-27)
+		      var localVar string       line 16
+		      block (lines 18-21)
+		        var bloclVar string     line 19
+
+		Every identifier is listed with its kind and type. When a name hides
+		one from an outer scope, both sides are marked.
+
+		Usage (the "--" stops go run from compiling the .go file being inspected):
+			go run main.go -- ../main.go				scope tree
+			go run main.go -- ../main.go:20				identifiers visible at line 20
+			go run main.go -json ../main.go				tree as JSON
+			go run main.go -json ../main.go:20			visible identifiers as JSON
+			go run main.go -universe ../main.go:20		include builtins (len, string, ...)
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Ident is one declared name.
+type Ident struct {
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Type       string `json:"type,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Scope      string `json:"scope,omitempty"`       // set by Visible: where the name lives
+	Shadows    string `json:"shadows,omitempty"`     // "var message (line 14)"
+	ShadowedBy []int  `json:"shadowed_by,omitempty"` // lines of the declarations hiding it
+}
+
+// Scope is one node of the scope tree.
+type Scope struct {
+	Kind      string   `json:"kind"` // package, file, func main, block, if, for, ...
+	StartLine int      `json:"start_line,omitempty"`
+	EndLine   int      `json:"end_line,omitempty"`
+	Idents    []*Ident `json:"idents,omitempty"`
+	Children  []*Scope `json:"children,omitempty"`
+}
+
+// program is a type-checked file.
+type program struct {
+	fset  *token.FileSet
+	file  *ast.File
+	pkg   *types.Package
+	info  *types.Info
+	kinds map[*types.Scope]string
+}
+
+func load(filename string) (*program, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Keep going on errors, so a file with mistakes still shows its scopes.
+		Error: func(error) {},
+	}
+	info := &types.Info{
+		Defs:   map[*ast.Ident]types.Object{},
+		Scopes: map[ast.Node]*types.Scope{},
+	}
+	pkg, _ := conf.Check(file.Name.Name, fset, []*ast.File{file}, info)
+
+	p := &program{fset: fset, file: file, pkg: pkg, info: info, kinds: map[*types.Scope]string{}}
+	p.labelScopes()
+	return p, nil
+}
+
+// labelScopes names each scope after the syntax that opened it.
+func (p *program) labelScopes() {
+	funcNames := map[*ast.FuncType]string{}
+	ast.Inspect(p.file, func(n ast.Node) bool {
+		if fd, ok := n.(*ast.FuncDecl); ok {
+			name := fd.Name.Name
+			if fd.Recv != nil && len(fd.Recv.List) > 0 {
+				name = "(" + types.ExprString(fd.Recv.List[0].Type) + ")." + name
+			}
+			funcNames[fd.Type] = name
+		}
+		return true
+	})
+
+	for node, scope := range p.info.Scopes {
+		kind := "block"
+		switch n := node.(type) {
+		case *ast.File:
+			kind = "file " + filepath.Base(p.fset.Position(n.Pos()).Filename)
+		case *ast.FuncType:
+			if name, ok := funcNames[n]; ok {
+				kind = "func " + name
+			} else {
+				kind = "func literal"
+			}
+		case *ast.IfStmt:
+			kind = "if"
+		case *ast.ForStmt:
+			kind = "for"
+		case *ast.RangeStmt:
+			kind = "range"
+		case *ast.SwitchStmt:
+			kind = "switch"
+		case *ast.TypeSwitchStmt:
+			kind = "type switch"
+		case *ast.CaseClause:
+			kind = "case"
+		case *ast.SelectStmt:
+			kind = "select"
+		case *ast.CommClause:
+			kind = "select case"
+		}
+		p.kinds[scope] = kind
+	}
+	p.kinds[p.pkg.Scope()] = "package " + p.pkg.Name()
+}
+
+func (p *program) line(pos token.Pos) int {
+	if !pos.IsValid() {
+		return 0
+	}
+	return p.fset.Position(pos).Line
+}
+
+func kindOf(obj types.Object) string {
+	switch o := obj.(type) {
+	case *types.Var:
+		return "var"
+	case *types.Const:
+		return "const"
+	case *types.TypeName:
+		return "type"
+	case *types.Func:
+		return "func"
+	case *types.PkgName:
+		return "package"
+	case *types.Label:
+		return "label"
+	case *types.Builtin:
+		return "builtin"
+	case *types.Nil:
+		return "nil"
+	default:
+		return fmt.Sprintf("%T", o)
+	}
+}
+
+// typeOf leaves out types that only repeat the kind (a package, a label).
+func typeOf(obj types.Object) string {
+	switch obj.(type) {
+	case *types.PkgName, *types.Label, *types.Builtin, *types.Nil:
+		return ""
+	}
+	if obj.Type() == nil {
+		return ""
+	}
+	return types.TypeString(obj.Type(), types.RelativeTo(obj.Pkg()))
+}
+
+// Tree builds the scope tree from the package scope down.
+func (p *program) Tree() *Scope {
+	idents := map[types.Object]*Ident{}
+	root := p.build(p.pkg.Scope(), idents)
+
+	// Mark shadowing: look each local name up in the scopes around it.
+	for obj, id := range idents {
+		scope := obj.Parent()
+		if scope == nil || scope == p.pkg.Scope() || scope.Parent() == nil {
+			continue
+		}
+		_, outer := scope.Parent().LookupParent(obj.Name(), obj.Pos())
+		if outer == nil || outer.Parent() == types.Universe {
+			continue
+		}
+		id.Shadows = fmt.Sprintf("%s %s (line %d)", kindOf(outer), outer.Name(), p.line(outer.Pos()))
+		if outerID, ok := idents[outer]; ok {
+			outerID.ShadowedBy = append(outerID.ShadowedBy, p.line(obj.Pos()))
+			sort.Ints(outerID.ShadowedBy)
+		}
+	}
+	return root
+}
+
+func (p *program) build(scope *types.Scope, idents map[types.Object]*Ident) *Scope {
+	node := &Scope{Kind: p.kinds[scope], StartLine: p.line(scope.Pos()), EndLine: p.line(scope.End())}
+	if node.Kind == "" {
+		node.Kind = "block"
+	}
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if name == "_" {
+			continue
+		}
+		id := &Ident{Name: name, Kind: kindOf(obj), Type: typeOf(obj), Line: p.line(obj.Pos())}
+		idents[obj] = id
+		node.Idents = append(node.Idents, id)
+	}
+	sort.Slice(node.Idents, func(i, j int) bool { return node.Idents[i].Line < node.Idents[j].Line })
+
+	for i := 0; i < scope.NumChildren(); i++ {
+		node.Children = append(node.Children, p.build(scope.Child(i), idents))
+	}
+	sort.SliceStable(node.Children, func(i, j int) bool { return node.Children[i].StartLine < node.Children[j].StartLine })
+	return node
+}
+
+// Visible lists every identifier that can be used at the start of line,
+// innermost scope first. Names hidden by an inner declaration are left out.
+func (p *program) Visible(line int, universe bool) ([]*Ident, error) {
+	tf := p.fset.File(p.file.Pos())
+	if line < 1 || line > tf.LineCount() {
+		return nil, fmt.Errorf("line %d is outside the file (1-%d)", line, tf.LineCount())
+	}
+	// Use the first non-blank column so the position is inside the line's scope.
+	pos := tf.LineStart(line)
+	src, _ := os.ReadFile(tf.Name())
+	offset := tf.Offset(pos)
+	for offset < len(src) && (src[offset] == ' ' || src[offset] == '\t') {
+		offset++
+	}
+	pos = tf.Pos(offset)
+
+	var out []*Ident
+	seen := map[string]bool{}
+	for scope := p.pkg.Scope().Innermost(pos); scope != nil; scope = scope.Parent() {
+		if scope == types.Universe && !universe {
+			break
+		}
+		kind := p.kinds[scope]
+		if scope == types.Universe {
+			kind = "universe"
+		}
+		names := scope.Names()
+		for _, name := range names {
+			obj := scope.Lookup(name)
+			// Locals exist only after their declaration; package names everywhere.
+			local := scope != p.pkg.Scope() && scope != types.Universe && !strings.HasPrefix(p.kinds[scope], "file")
+			if name == "_" || seen[name] || (local && obj.Pos() >= pos) {
+				continue
+			}
+			seen[name] = true
+			out = append(out, &Ident{Name: name, Kind: kindOf(obj), Type: typeOf(obj), Line: p.line(obj.Pos()), Scope: kind})
+		}
+	}
+	return out, nil
+}
+
+func printTree(s *Scope, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if s.StartLine > 0 && !strings.HasPrefix(s.Kind, "file") {
+		fmt.Printf("%s%s (lines %d-%d)\n", indent, s.Kind, s.StartLine, s.EndLine)
+	} else {
+		fmt.Printf("%s%s\n", indent, s.Kind)
+	}
+	for _, id := range s.Idents {
+		decl := strings.TrimSpace(id.Kind + " " + id.Name + " " + id.Type)
+		note := ""
+		if id.Line > 0 {
+			note = fmt.Sprintf("line %d", id.Line)
+		}
+		if id.Shadows != "" {
+			note += ", shadows " + id.Shadows
+		}
+		if len(id.ShadowedBy) > 0 {
+			lines := make([]string, len(id.ShadowedBy))
+			for i, line := range id.ShadowedBy {
+				lines[i] = fmt.Sprintf("line %d", line)
+			}
+			note += ", shadowed at " + strings.Join(lines, ", ")
+		}
+		fmt.Printf("%s  %-36s %s\n", indent, decl, note)
+	}
+	for _, c := range s.Children {
+		printTree(c, depth+1)
+	}
+}
+
+func main() {
+	asJSON := flag.Bool("json", false, "print JSON")
+	universe := flag.Bool("universe", false, "include predeclared identifiers when listing visible names")
+	flag.Parse()
+
+	arg := "../main.go"
+	if flag.NArg() > 0 {
+		arg = flag.Arg(0)
+	}
+	filename, line := arg, 0
+	if i := strings.LastIndex(arg, ":"); i > 0 {
+		if n, err := strconv.Atoi(arg[i+1:]); err == nil {
+			filename, line = arg[:i], n
+		}
+	}
+
+	p, err := load(filename)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	var result any
+	if line > 0 {
+		visible, err := p.Visible(line, *universe)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		result = visible
+		if !*asJSON {
+			fmt.Printf("Visible at %s:%d (innermost first):\n", filepath.Base(filename), line)
+			for _, id := range visible {
+				decl := strings.TrimSpace(id.Kind + " " + id.Name + " " + id.Type)
+				where := id.Scope
+				if id.Line > 0 {
+					where += fmt.Sprintf(", line %d", id.Line)
+				}
+				fmt.Printf("  %-36s %s\n", decl, where)
+			}
+			return
+		}
+	} else {
+		tree := p.Tree()
+		result = tree
+		if !*asJSON {
+			printTree(tree, 0)
+			return
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -5,10 +5,19 @@ import "fmt"
 /*
 	- iota is a special constant used to create sequential numbers.
 	- Its value starts at 0 and increments automatically.
+	- Giving the constants a named type (Weekday, Tier) lets us attach methods,
+	  so fmt.Println(Wednesday) prints "Wednesday" instead of 3.
+	- The methods live in *_enum.go and are written by ../3-enum-generator.
+
+	Run: go run main.go weekday_enum.go tier_enum.go
 */
 
+//go:generate go run ../3-enum-generator/main.go -type=Weekday,Tier
+
+type Weekday int
+
 const (
-	Sunday = iota
+	Sunday Weekday = iota
 	Monday
 	Tuesday
 	Wednesday
@@ -17,12 +26,14 @@ const (
 	Saturday
 )
 
+type Tier int
+
 // Use _ to skip a value.
 const (
-	_        = iota // Skips 0
-	Silver          // 1
-	Gold            // 2
-	Platinum        // 3
+	_        Tier = iota // Skips 0
+	Silver               // 1
+	Gold                 // 2
+	Platinum             // 3
 )
 
 func main() {
@@ -36,4 +47,22 @@ func main() {
 	fmt.Println("Silver:", Silver)
 	fmt.Println("Gold:", Gold)
 	fmt.Println("Platinum:", Platinum)
+
+	fmt.Println("Generated enum methods.")
+
+	day, err := ParseWeekday("wednesday")
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Println("Parsed:", day, int(day))
+	}
+
+	fmt.Println("Tier(0) valid?", Tier(0).IsValid()) // false, 0 was skipped
+	fmt.Println("All tiers:", TierValues())
+
+	Gold.Switch(
+		func() { fmt.Println("Silver member") },
+		func() { fmt.Println("Gold member") },
+		func() { fmt.Println("Platinum member") },
+	)
 }
@@ -0,0 +1,102 @@
+// Code generated by "enum-generator -type=Tier"; DO NOT EDIT.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the constant name of tier, or Tier(n) for unknown values.
+func (v Tier) String() string {
+	switch v {
+	case Silver:
+		return "Silver"
+	case Gold:
+		return "Gold"
+	case Platinum:
+		return "Platinum"
+	}
+	return "Tier(" + strconv.FormatInt(int64(v), 10) + ")"
+}
+
+// TierValues returns every declared Tier in declaration order.
+func TierValues() []Tier {
+	return []Tier{Silver, Gold, Platinum}
+}
+
+// IsValid reports whether v is one of the declared Tier constants.
+func (v Tier) IsValid() bool {
+	switch v {
+	case Silver, Gold, Platinum:
+		return true
+	}
+	return false
+}
+
+// ParseTier converts a constant name (case-insensitive) back to a Tier.
+func ParseTier(s string) (Tier, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "silver":
+		return Silver, nil
+	case "gold":
+		return Gold, nil
+	case "platinum":
+		return Platinum, nil
+	}
+	return 0, fmt.Errorf("invalid Tier: %q", s)
+}
+
+// MarshalText encodes v as its constant name.
+func (v Tier) MarshalText() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Tier: %d", int64(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a constant name into v.
+func (v *Tier) UnmarshalText(text []byte) error {
+	parsed, err := ParseTier(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON encodes v as a JSON string holding its constant name.
+func (v Tier) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a JSON string holding a constant name into v.
+func (v *Tier) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Tier should be a string, got %s", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Switch calls the function matching v. It takes one function per constant,
+// so adding a constant to Tier breaks every caller until the new case is handled.
+// Invalid values panic.
+func (v Tier) Switch(silver, gold, platinum func()) {
+	switch v {
+	case Silver:
+		silver()
+	case Gold:
+		gold()
+	case Platinum:
+		platinum()
+	default:
+		panic("Tier.Switch: invalid value " + v.String())
+	}
+}
@@ -0,0 +1,345 @@
+/*
+	Enum Generator (go generate tool):
+
+		- iota constants are just numbers, so fmt.Println(Wednesday) prints 3.
+		- Giving the constants a named type (type Weekday int) lets us attach methods.
+		- This tool reads the const blocks of a named type and writes those methods for us.
+
+	Generated for every type T:
+
+		String()        -> "Wednesday" instead of 3
+		ParseT(s)       -> string back to T (case-insensitive)
+		TValues()       -> every declared value, in declaration order
+		IsValid()       -> false for skipped (_) or unknown values
+		MarshalText / UnmarshalText / MarshalJSON / UnmarshalJSON
+		Switch(...)     -> one func per constant, so adding a constant breaks every
+		                   call site until it is handled (exhaustive switch)
+
+	Usage (inside the package that declares the constants):
+
+		//go:generate go run ../3-enum-generator/main.go -type=Weekday,Tier
+
+		go generate main.go
+
+	Constants whose names differ only in case, or a constant named V, are
+	reported as errors: ParseT and Switch could not be generated for them.
+
+	testdata/ holds small input packages and the code generated for them:
+
+		go test main.go main_test.go
+		go test main.go main_test.go -update		rewrite the golden files
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// enumValue is one named constant of an enum type.
+type enumValue struct {
+	name  string
+	value int64
+}
+
+// enumType collects the constants declared for one named integer type.
+type enumType struct {
+	name   string
+	values []enumValue
+}
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of type names (required)")
+	dir := flag.String("dir", ".", "directory of the package to scan")
+	output := flag.String("output", "", "output file name (default <type>_enum.go, one file per type)")
+	flag.Parse()
+
+	if *typeNames == "" {
+		fmt.Fprintln(os.Stderr, "enum-generator: -type is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	pkgName, enums, err := loadEnums(*dir, strings.Split(*typeNames, ","))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "enum-generator:", err)
+		os.Exit(1)
+	}
+
+	if *output != "" {
+		src, err := generate(pkgName, enums...)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "enum-generator:", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(filepath.Join(*dir, *output), src, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "enum-generator:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for _, e := range enums {
+		src, err := generate(pkgName, e)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "enum-generator:", err)
+			os.Exit(1)
+		}
+		name := strings.ToLower(e.name) + "_enum.go"
+		if err := os.WriteFile(filepath.Join(*dir, name), src, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "enum-generator:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// loadEnums parses and type-checks the package in dir and returns the
+// constants of every requested type. Type checking does the iota math for us,
+// including skipped "_" values and expressions like iota + 10.
+func loadEnums(dir string, typeNames []string) (string, []enumType, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("expected exactly one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var pkgName string
+	var files []*ast.File
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, f := range pkg.Files {
+			files = append(files, f)
+		}
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Keep going on errors: a half-written package still has usable constants.
+		Error: func(error) {},
+	}
+	info := &types.Info{Defs: map[*ast.Ident]types.Object{}}
+	conf.Check(pkgName, fset, files, info)
+
+	var enums []enumType
+	for _, typeName := range typeNames {
+		typeName = strings.TrimSpace(typeName)
+		e := enumType{name: typeName}
+
+		// Walk files in source order so values come out in declaration order.
+		for _, f := range sortedFiles(fset, files) {
+			for _, decl := range f.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					for _, ident := range spec.(*ast.ValueSpec).Names {
+						if ident.Name == "_" {
+							continue
+						}
+						obj, ok := info.Defs[ident].(*types.Const)
+						if !ok || obj.Type().String() != pkgName+"."+typeName {
+							continue
+						}
+						v, exact := constant.Int64Val(obj.Val())
+						if !exact {
+							return "", nil, fmt.Errorf("%s: value of %s does not fit in int64", typeName, ident.Name)
+						}
+						e.values = append(e.values, enumValue{name: ident.Name, value: v})
+					}
+				}
+			}
+		}
+
+		if len(e.values) == 0 {
+			return "", nil, fmt.Errorf("no constants of type %s found in %s", typeName, dir)
+		}
+		if err := checkNames(e); err != nil {
+			return "", nil, err
+		}
+		enums = append(enums, e)
+	}
+	return pkgName, enums, nil
+}
+
+// checkNames rejects constants the generated code can't tell apart:
+// names that differ only in case would be the same case in ParseT, and a
+// constant named V would give Switch a parameter v, the receiver's name.
+func checkNames(e enumType) error {
+	seen := map[string]string{}
+	for _, v := range e.values {
+		lower := strings.ToLower(v.name)
+		if first, dup := seen[lower]; dup {
+			return fmt.Errorf("%s: %s and %s differ only in case, so Parse%s can't tell them apart", e.name, first, v.name, e.name)
+		}
+		seen[lower] = v.name
+	}
+	for _, v := range uniqueValues(e.values) {
+		if lowerFirst(v.name) == "v" {
+			return fmt.Errorf("%s: constant %s would name a Switch parameter v, which is the receiver", e.name, v.name)
+		}
+	}
+	return nil
+}
+
+// sortedFiles orders the parsed files by file name.
+func sortedFiles(fset *token.FileSet, files []*ast.File) []*ast.File {
+	sorted := append([]*ast.File(nil), files...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && fset.File(sorted[j].Pos()).Name() < fset.File(sorted[j-1].Pos()).Name(); j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+	return sorted
+}
+
+// generate writes the methods for the given enums as one gofmt'ed file.
+func generate(pkgName string, enums ...enumType) ([]byte, error) {
+	var buf bytes.Buffer
+	args := "-type="
+	for i, e := range enums {
+		if i > 0 {
+			args += ","
+		}
+		args += e.name
+	}
+
+	fmt.Fprintf(&buf, "// Code generated by \"enum-generator %s\"; DO NOT EDIT.\n\n", args)
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	fmt.Fprintf(&buf, "import (\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"strconv\"\n\t\"strings\"\n)\n")
+
+	for _, e := range enums {
+		writeEnum(&buf, e)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func writeEnum(buf *bytes.Buffer, e enumType) {
+	t := e.name
+
+	// String
+	fmt.Fprintf(buf, "\n// String returns the constant name of %s, or %s(n) for unknown values.\n", lowerFirst(t), t)
+	fmt.Fprintf(buf, "func (v %s) String() string {\n\tswitch v {\n", t)
+	for _, v := range uniqueValues(e.values) {
+		fmt.Fprintf(buf, "\tcase %s:\n\t\treturn %q\n", v.name, v.name)
+	}
+	fmt.Fprintf(buf, "\t}\n\treturn \"%s(\" + strconv.FormatInt(int64(v), 10) + \")\"\n}\n", t)
+
+	// Values
+	fmt.Fprintf(buf, "\n// %sValues returns every declared %s in declaration order.\n", t, t)
+	fmt.Fprintf(buf, "func %sValues() []%s {\n\treturn []%s{", t, t, t)
+	for i, v := range e.values {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(v.name)
+	}
+	buf.WriteString("}\n}\n")
+
+	// IsValid
+	fmt.Fprintf(buf, "\n// IsValid reports whether v is one of the declared %s constants.\n", t)
+	fmt.Fprintf(buf, "func (v %s) IsValid() bool {\n\tswitch v {\n\tcase ", t)
+	for i, v := range uniqueValues(e.values) {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(v.name)
+	}
+	buf.WriteString(":\n\t\treturn true\n\t}\n\treturn false\n}\n")
+
+	// Parse
+	fmt.Fprintf(buf, "\n// Parse%s converts a constant name (case-insensitive) back to a %s.\n", t, t)
+	fmt.Fprintf(buf, "func Parse%s(s string) (%s, error) {\n\tswitch strings.ToLower(strings.TrimSpace(s)) {\n", t, t)
+	for _, v := range e.values {
+		fmt.Fprintf(buf, "\tcase %q:\n\t\treturn %s, nil\n", strings.ToLower(v.name), v.name)
+	}
+	fmt.Fprintf(buf, "\t}\n\treturn 0, fmt.Errorf(\"invalid %s: %%q\", s)\n}\n", t)
+
+	// Text marshalling
+	fmt.Fprintf(buf, "\n// MarshalText encodes v as its constant name.\n")
+	fmt.Fprintf(buf, "func (v %s) MarshalText() ([]byte, error) {\n", t)
+	fmt.Fprintf(buf, "\tif !v.IsValid() {\n\t\treturn nil, fmt.Errorf(\"invalid %s: %%d\", int64(v))\n\t}\n", t)
+	buf.WriteString("\treturn []byte(v.String()), nil\n}\n")
+
+	fmt.Fprintf(buf, "\n// UnmarshalText decodes a constant name into v.\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalText(text []byte) error {\n", t)
+	fmt.Fprintf(buf, "\tparsed, err := Parse%s(string(text))\n\tif err != nil {\n\t\treturn err\n\t}\n\t*v = parsed\n\treturn nil\n}\n", t)
+
+	// JSON marshalling
+	fmt.Fprintf(buf, "\n// MarshalJSON encodes v as a JSON string holding its constant name.\n")
+	fmt.Fprintf(buf, "func (v %s) MarshalJSON() ([]byte, error) {\n", t)
+	buf.WriteString("\ttext, err := v.MarshalText()\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn json.Marshal(string(text))\n}\n")
+
+	fmt.Fprintf(buf, "\n// UnmarshalJSON decodes a JSON string holding a constant name into v.\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalJSON(data []byte) error {\n", t)
+	buf.WriteString("\tvar s string\n\tif err := json.Unmarshal(data, &s); err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn fmt.Errorf(\"%s should be a string, got %%s\", data)\n\t}\n", t)
+	buf.WriteString("\treturn v.UnmarshalText([]byte(s))\n}\n")
+
+	// Exhaustive switch
+	fmt.Fprintf(buf, "\n// Switch calls the function matching v. It takes one function per constant,\n")
+	fmt.Fprintf(buf, "// so adding a constant to %s breaks every caller until the new case is handled.\n", t)
+	fmt.Fprintf(buf, "// Invalid values panic.\n")
+	fmt.Fprintf(buf, "func (v %s) Switch(", t)
+	unique := uniqueValues(e.values)
+	for i, v := range unique {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(lowerFirst(v.name))
+	}
+	buf.WriteString(" func()) {\n\tswitch v {\n")
+	for _, v := range unique {
+		fmt.Fprintf(buf, "\tcase %s:\n\t\t%s()\n", v.name, lowerFirst(v.name))
+	}
+	fmt.Fprintf(buf, "\tdefault:\n\t\tpanic(\"%s.Switch: invalid value \" + v.String())\n\t}\n}\n", t)
+}
+
+// uniqueValues drops aliases (two names with the same value), keeping the
+// first name, so switch statements don't get duplicate cases.
+func uniqueValues(values []enumValue) []enumValue {
+	seen := map[int64]bool{}
+	var unique []enumValue
+	for _, v := range values {
+		if seen[v.value] {
+			continue
+		}
+		seen[v.value] = true
+		unique = append(unique, v)
+	}
+	return unique
+}
+
+// lowerFirst turns an exported name into a parameter name (Gold -> gold).
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	name := string(r)
+	if token.IsKeyword(name) {
+		name += "_"
+	}
+	return name
+}
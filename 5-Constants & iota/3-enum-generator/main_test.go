@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+func TestGolden(t *testing.T) {
+	pkgName, enums, err := loadEnums(filepath.Join("testdata", "basic"), []string{"Color", " Level"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate(pkgName, enums...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "basic.golden")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated code differs from %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+	}
+}
+
+func TestNameErrors(t *testing.T) {
+	tests := []struct {
+		dir, typ string
+		want     string
+	}{
+		{"casedup", "Mode", "Fast and FAST differ only in case"},
+		{"receiver", "Axis", "constant V would name a Switch parameter v"},
+		{"basic", "Missing", "no constants of type Missing"},
+	}
+	for _, tt := range tests {
+		_, _, err := loadEnums(filepath.Join("testdata", tt.dir), []string{tt.typ})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.dir, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package basic is the input for the golden test: skipped values, an
+// alias, an offset iota and a constant whose name is a keyword.
+package basic
+
+type Color int
+
+const (
+	_ Color = iota
+	Red
+	Green
+	Blue
+	Primary = Red // an alias: no second case in String or Switch
+)
+
+type Level uint8
+
+const (
+	Debug Level = iota + 10
+	Info
+	Default
+	Warn = Default + 5
+)
+
+// Unrelated constants are left alone.
+const Answer = 42
@@ -0,0 +1,9 @@
+package casedup
+
+type Mode int
+
+const (
+	Fast Mode = iota
+	Slow
+	FAST
+)
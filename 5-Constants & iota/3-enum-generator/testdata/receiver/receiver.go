@@ -0,0 +1,8 @@
+package receiver
+
+type Axis int
+
+const (
+	H Axis = iota
+	V
+)
@@ -0,0 +1,364 @@
+/*
+	Bit Flags with iota:
+
+		- "1 << iota" gives every constant its own bit: 1, 2, 4, 8, ...
+		- Several flags can be stored in one number and combined with |.
+
+		Operation		Expression			Example (Read=1, Write=2)
+		Set				flags |= f			0 | Write -> 2
+		Clear			flags &^= f			3 &^ Write -> 1
+		Toggle			flags ^= f			1 ^ Write -> 3
+		Has				flags&f == f		3&Write == Write -> true
+
+	Flags[T] wraps those operations for any flag type T whose single bits have a
+	String() name (here written by ../3-enum-generator), so a set prints as "Read|Write".
+
+	BitSet is the large variant: an unbounded set of bit positions backed by []uint64.
+
+	Run: go run main.go permission_enum.go
+*/
+
+package main
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ../3-enum-generator/main.go -type=Permission
+
+type Permission uint8
+
+const (
+	Read    Permission = 1 << iota // 1
+	Write                          // 2
+	Execute                        // 4
+	Delete                         // 8
+)
+
+// Flag is a type whose single-bit values have names.
+type Flag interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uint
+	String() string
+}
+
+// Flags is a set of flags of type T. The zero value is an empty set.
+type Flags[T Flag] struct {
+	bits T
+}
+
+// FlagsOf returns a set holding the given flags.
+func FlagsOf[T Flag](flags ...T) Flags[T] {
+	var f Flags[T]
+	f.Set(flags...)
+	return f
+}
+
+// Value returns the raw combined number.
+func (f Flags[T]) Value() T {
+	return f.bits
+}
+
+func (f *Flags[T]) Set(flags ...T) {
+	for _, flag := range flags {
+		f.bits |= flag
+	}
+}
+
+func (f *Flags[T]) Clear(flags ...T) {
+	for _, flag := range flags {
+		f.bits &^= flag
+	}
+}
+
+func (f *Flags[T]) Toggle(flags ...T) {
+	for _, flag := range flags {
+		f.bits ^= flag
+	}
+}
+
+// Has reports whether every bit of flag is set.
+func (f Flags[T]) Has(flag T) bool {
+	return f.bits&flag == flag
+}
+
+// HasAll reports whether every one of flags is set.
+func (f Flags[T]) HasAll(flags ...T) bool {
+	for _, flag := range flags {
+		if !f.Has(flag) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny reports whether at least one of flags is set.
+func (f Flags[T]) HasAny(flags ...T) bool {
+	for _, flag := range flags {
+		if f.bits&flag != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// All yields every set flag, lowest bit first.
+func (f Flags[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for rest := uint64(f.bits); rest != 0; rest &= rest - 1 {
+			if !yield(T(1) << bits.TrailingZeros64(rest)) {
+				return
+			}
+		}
+	}
+}
+
+// String joins the names of the set flags with "|", e.g. "Read|Write".
+// An empty set prints as "0". A bit whose String() could not be read back
+// (empty, or holding "|") prints as its number, so ParseFlags always
+// returns the same set.
+func (f Flags[T]) String() string {
+	var names []string
+	for flag := range f.All() {
+		name := flag.String()
+		if name == "" || strings.Contains(name, "|") {
+			name = strconv.FormatUint(uint64(flag), 10)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "|")
+}
+
+// ParseFlags reads the String form back, e.g. "Read|Write" or "read | write".
+// Names are matched case-insensitively against the String() of every bit of
+// T, so unnamed bits work in their "Permission(16)" form; a plain number
+// such as 16 or 0x10 sets its bits directly.
+func ParseFlags[T Flag](s string) (Flags[T], error) {
+	var f Flags[T]
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return f, nil
+	}
+
+	width := bitWidth[T]()
+	for _, part := range strings.Split(s, "|") {
+		name := strings.TrimSpace(part)
+		found := false
+		for i := 0; i < width; i++ {
+			flag := T(1) << i
+			if strings.EqualFold(flag.String(), name) {
+				f.Set(flag)
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if n, err := strconv.ParseUint(name, 0, width); err == nil {
+			f.Set(T(n))
+			continue
+		}
+		return Flags[T]{}, fmt.Errorf("unknown flag %q in %q", name, s)
+	}
+	return f, nil
+}
+
+// bitWidth returns the number of bits in T.
+func bitWidth[T Flag]() int {
+	var zero T
+	return bits.Len64(uint64(^zero))
+}
+
+// BitSet is a set of non-negative integers backed by []uint64, for sets that
+// don't fit in a single integer. The zero value is an empty set.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet returns a set holding the given positions.
+func NewBitSet(positions ...int) *BitSet {
+	b := &BitSet{}
+	for _, p := range positions {
+		b.Set(p)
+	}
+	return b
+}
+
+// Set adds position i. Like a slice index, a negative position panics.
+func (b *BitSet) Set(i int) {
+	checkPosition(i)
+	w := i / 64
+	for len(b.words) <= w {
+		b.words = append(b.words, 0)
+	}
+	b.words[w] |= 1 << (i % 64)
+}
+
+// Clear removes position i; clearing a position that isn't set does nothing.
+// A negative position panics.
+func (b *BitSet) Clear(i int) {
+	checkPosition(i)
+	if w := i / 64; w < len(b.words) {
+		b.words[w] &^= 1 << (i % 64)
+	}
+}
+
+// Toggle flips position i. A negative position panics.
+func (b *BitSet) Toggle(i int) {
+	checkPosition(i)
+	if b.Has(i) {
+		b.Clear(i)
+	} else {
+		b.Set(i)
+	}
+}
+
+// Has reports whether position i is set. A negative position never is.
+func (b *BitSet) Has(i int) bool {
+	w := i / 64
+	return i >= 0 && w < len(b.words) && b.words[w]&(1<<(i%64)) != 0
+}
+
+// checkPosition panics for a negative position, the way an index out of
+// range does, instead of silently ignoring it.
+func checkPosition(i int) {
+	if i < 0 {
+		panic("BitSet: negative position " + strconv.Itoa(i))
+	}
+}
+
+// Count returns the number of set bits (population count).
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// All yields every set position in increasing order.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for wi, w := range b.words {
+			for ; w != 0; w &= w - 1 {
+				if !yield(wi*64 + bits.TrailingZeros64(w)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Union returns the positions set in b or other.
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	return combine(b, other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Intersect returns the positions set in both b and other.
+func (b *BitSet) Intersect(other *BitSet) *BitSet {
+	return combine(b, other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Difference returns the positions set in b but not in other.
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	return combine(b, other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// SymmetricDifference returns the positions set in exactly one of b and other.
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	return combine(b, other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// Equal reports whether b and other hold the same positions.
+func (b *BitSet) Equal(other *BitSet) bool {
+	return b.SymmetricDifference(other).Count() == 0
+}
+
+// String prints the set positions, e.g. "{1 5 64}".
+func (b *BitSet) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range b.All() {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.Itoa(i))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// combine applies op word by word, treating missing words as zero.
+func combine(a, b *BitSet, op func(x, y uint64) uint64) *BitSet {
+	n := max(len(a.words), len(b.words))
+	out := &BitSet{words: make([]uint64, n)}
+	for i := range out.words {
+		var x, y uint64
+		if i < len(a.words) {
+			x = a.words[i]
+		}
+		if i < len(b.words) {
+			y = b.words[i]
+		}
+		out.words[i] = op(x, y)
+	}
+	return out
+}
+
+func main() {
+
+	fmt.Println("Permission flags:", Read, Write, Execute, Delete) // Read Write Execute Delete
+	fmt.Println("Raw values:", int(Read), int(Write), int(Execute), int(Delete))
+
+	perms := FlagsOf(Read, Write)
+	fmt.Println("perms:", perms)                                        // Read|Write
+	fmt.Println("Has Write?", perms.Has(Write))                         // true
+	fmt.Println("HasAll Read, Execute?", perms.HasAll(Read, Execute))   // false
+	fmt.Println("HasAny Execute, Write?", perms.HasAny(Execute, Write)) // true
+
+	perms.Set(Execute)
+	perms.Clear(Write)
+	perms.Toggle(Delete)
+	fmt.Println("After Set/Clear/Toggle:", perms, "=", int(perms.Value())) // Read|Execute|Delete
+
+	for flag := range perms.All() {
+		fmt.Println(" -", flag)
+	}
+
+	parsed, err := ParseFlags[Permission]("write | READ")
+	if err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Println("Parsed:", parsed)
+	}
+
+	if _, err := ParseFlags[Permission]("Read|Admin"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// Bits without a constant still round-trip.
+	odd := FlagsOf(Read, Permission(16))
+	back, _ := ParseFlags[Permission](odd.String())
+	fmt.Println("Unnamed bit:", odd, "->", back, back == odd) // Read|Permission(16) -> Read|Permission(16) true
+	fromNumber, _ := ParseFlags[Permission]("Write|0x11")
+	fmt.Println("With a number:", fromNumber) // Read|Write|Permission(16)
+
+	fmt.Println("Large bit set:")
+
+	a := NewBitSet(1, 5, 64, 200)
+	b := NewBitSet(5, 64, 65)
+	fmt.Println("a:", a, "count:", a.Count())
+	fmt.Println("b:", b, "count:", b.Count())
+	fmt.Println("a ∪ b:", a.Union(b))
+	fmt.Println("a ∩ b:", a.Intersect(b))
+	fmt.Println("a - b:", a.Difference(b))
+	fmt.Println("a △ b:", a.SymmetricDifference(b))
+	fmt.Println("a == b?", a.Equal(b))
+}
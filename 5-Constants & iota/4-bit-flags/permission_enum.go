@@ -0,0 +1,108 @@
+// Code generated by "enum-generator -type=Permission"; DO NOT EDIT.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the constant name of permission, or Permission(n) for unknown values.
+func (v Permission) String() string {
+	switch v {
+	case Read:
+		return "Read"
+	case Write:
+		return "Write"
+	case Execute:
+		return "Execute"
+	case Delete:
+		return "Delete"
+	}
+	return "Permission(" + strconv.FormatInt(int64(v), 10) + ")"
+}
+
+// PermissionValues returns every declared Permission in declaration order.
+func PermissionValues() []Permission {
+	return []Permission{Read, Write, Execute, Delete}
+}
+
+// IsValid reports whether v is one of the declared Permission constants.
+func (v Permission) IsValid() bool {
+	switch v {
+	case Read, Write, Execute, Delete:
+		return true
+	}
+	return false
+}
+
+// ParsePermission converts a constant name (case-insensitive) back to a Permission.
+func ParsePermission(s string) (Permission, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "read":
+		return Read, nil
+	case "write":
+		return Write, nil
+	case "execute":
+		return Execute, nil
+	case "delete":
+		return Delete, nil
+	}
+	return 0, fmt.Errorf("invalid Permission: %q", s)
+}
+
+// MarshalText encodes v as its constant name.
+func (v Permission) MarshalText() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Permission: %d", int64(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a constant name into v.
+func (v *Permission) UnmarshalText(text []byte) error {
+	parsed, err := ParsePermission(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON encodes v as a JSON string holding its constant name.
+func (v Permission) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a JSON string holding a constant name into v.
+func (v *Permission) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Permission should be a string, got %s", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Switch calls the function matching v. It takes one function per constant,
+// so adding a constant to Permission breaks every caller until the new case is handled.
+// Invalid values panic.
+func (v Permission) Switch(read, write, execute, delete func()) {
+	switch v {
+	case Read:
+		read()
+	case Write:
+		write()
+	case Execute:
+		execute()
+	case Delete:
+		delete()
+	default:
+		panic("Permission.Switch: invalid value " + v.String())
+	}
+}
@@ -0,0 +1,291 @@
+/*
+	Weekday & Business Calendar:
+
+		- Our Sunday..Saturday iota constants use the same numbers as time.Weekday
+		  (Sunday = 0 ... Saturday = 6), so converting is a plain type conversion.
+		- A Calendar knows which day starts the week, which days are the weekend,
+		  and which dates are holidays (loaded from a file).
+
+		Method							Example
+		NextBusinessDay(t)				Thu 24 Dec 2026 -> Mon 28 Dec (holiday + weekend skipped)
+		AddBusinessDays(t, n)			n can be negative to go back in time
+		BusinessDaysBetween(a, b)		business days in [a, b)
+		ISOWeek(t)						2026-W43
+
+	Holiday file format (one date per line, # starts a comment):
+
+		2026-12-25 Christmas Day
+
+	Run: go run main.go weekday_enum.go
+*/
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate go run ../3-enum-generator/main.go -type=Weekday
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+const dateLayout = "2006-01-02"
+
+// FromTime converts a time.Weekday to our Weekday.
+func FromTime(d time.Weekday) Weekday {
+	return Weekday(d)
+}
+
+// Time converts d to a time.Weekday.
+func (d Weekday) Time() time.Weekday {
+	return time.Weekday(d)
+}
+
+// namesMu guards weekdayNames: RegisterLocale can add a language while
+// other goroutines call Localized.
+var namesMu sync.RWMutex
+
+// weekdayNames holds the localized full names, indexed by Weekday.
+var weekdayNames = map[string][7]string{
+	"en": {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	"fr": {"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	"de": {"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	"es": {"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	"hi": {"रविवार", "सोमवार", "मंगलवार", "बुधवार", "गुरुवार", "शुक्रवार", "शनिवार"},
+}
+
+// RegisterLocale adds or replaces the day names for a language.
+func RegisterLocale(lang string, names [7]string) {
+	namesMu.Lock()
+	defer namesMu.Unlock()
+	weekdayNames[lang] = names
+}
+
+// Localized returns the name of d in lang, falling back to English.
+func (d Weekday) Localized(lang string) string {
+	if !d.IsValid() {
+		return d.String()
+	}
+	namesMu.RLock()
+	defer namesMu.RUnlock()
+	names, ok := weekdayNames[lang]
+	if !ok {
+		names = weekdayNames["en"]
+	}
+	return names[d]
+}
+
+// Calendar decides which dates are business days. A literal &Calendar{...}
+// works too; holidays is created on the first AddHoliday.
+type Calendar struct {
+	WeekStart Weekday
+	Weekend   map[Weekday]bool
+	holidays  map[string]string // "2006-01-02" -> holiday name
+}
+
+// NewCalendar returns a calendar with a Monday week start, a Saturday/Sunday
+// weekend and no holidays.
+func NewCalendar() *Calendar {
+	return &Calendar{
+		WeekStart: Monday,
+		Weekend:   map[Weekday]bool{Saturday: true, Sunday: true},
+		holidays:  map[string]string{},
+	}
+}
+
+// AddHoliday marks a date as a holiday.
+func (c *Calendar) AddHoliday(date time.Time, name string) {
+	if c.holidays == nil {
+		c.holidays = map[string]string{}
+	}
+	c.holidays[date.Format(dateLayout)] = name
+}
+
+// LoadHolidays reads holidays from a file with one "YYYY-MM-DD name" per line.
+func (c *Calendar) LoadHolidays(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			continue
+		}
+
+		dateText, name, _ := strings.Cut(line, " ")
+		date, err := time.Parse(dateLayout, dateText)
+		if err != nil {
+			return fmt.Errorf("%s:%d: invalid date %q", path, lineNo, dateText)
+		}
+		c.AddHoliday(date, strings.TrimSpace(name))
+	}
+	return scanner.Err()
+}
+
+// Holiday returns the holiday name for t, if any.
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[t.Format(dateLayout)]
+	return name, ok
+}
+
+// IsBusinessDay reports whether t is neither a weekend day nor a holiday.
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if c.Weekend[FromTime(t.Weekday())] {
+		return false
+	}
+	_, holiday := c.Holiday(t)
+	return !holiday
+}
+
+// NextBusinessDay returns the first business day after t.
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	return c.step(t, 1)
+}
+
+// PrevBusinessDay returns the last business day before t.
+func (c *Calendar) PrevBusinessDay(t time.Time) time.Time {
+	return c.step(t, -1)
+}
+
+// AddBusinessDays moves n business days from t (backwards when n < 0).
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	direction := 1
+	if n < 0 {
+		direction, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		t = c.step(t, direction)
+	}
+	return t
+}
+
+// BusinessDaysBetween counts business days in [from, to). It is negative when
+// to is before from.
+func (c *Calendar) BusinessDaysBetween(from, to time.Time) int {
+	from, to = dateOnly(from), dateOnly(to)
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	count := 0
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// WeekDays returns the seven days in order, starting from c.WeekStart.
+func (c *Calendar) WeekDays() []Weekday {
+	days := make([]Weekday, 7)
+	for i := range days {
+		days[i] = (c.WeekStart + Weekday(i)) % 7
+	}
+	return days
+}
+
+// StartOfWeek returns the date of the first day of the week containing t.
+func (c *Calendar) StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) - int(c.WeekStart) + 7) % 7
+	return dateOnly(t).AddDate(0, 0, -offset)
+}
+
+// step walks one day at a time in direction until it lands on a business day.
+// A calendar where every day is a weekend day would loop forever, so it gives
+// up after a year.
+func (c *Calendar) step(t time.Time, direction int) time.Time {
+	d := dateOnly(t)
+	for i := 0; i < 366; i++ {
+		d = d.AddDate(0, 0, direction)
+		if c.IsBusinessDay(d) {
+			return d
+		}
+	}
+	panic("calendar has no business days")
+}
+
+// ISOWeek renders the ISO 8601 week of t, e.g. "2026-W43".
+func ISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// ISOWeekDate renders the ISO 8601 week date of t, e.g. "2026-W43-1" for a Monday.
+func ISOWeekDate(t time.Time) string {
+	day := int(t.Weekday())
+	if day == 0 {
+		day = 7 // ISO weeks run Monday (1) to Sunday (7)
+	}
+	return fmt.Sprintf("%s-%d", ISOWeek(t), day)
+}
+
+// dateOnly drops the clock part of t, keeping its location.
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func main() {
+
+	// The switch lesson maps day := 3 to a name by hand; the type does it for us.
+	day := Weekday(3)
+	fmt.Println("day 3 is", day)                              // Wednesday
+	fmt.Println("as time.Weekday:", day.Time())               // Wednesday
+	fmt.Println("in French:", day.Localized("fr"))            // mercredi
+	fmt.Println("from time:", FromTime(time.Now().Weekday())) // today
+
+	cal := NewCalendar()
+	if err := cal.LoadHolidays("holidays.txt"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	christmasEve := time.Date(2026, time.December, 24, 0, 0, 0, 0, time.UTC)
+	fmt.Println("Week starts on:", cal.WeekDays())
+	fmt.Println("Is", christmasEve.Format(dateLayout), "a business day?", cal.IsBusinessDay(christmasEve))
+
+	if name, ok := cal.Holiday(christmasEve.AddDate(0, 0, 1)); ok {
+		fmt.Println("Next day is a holiday:", name)
+	}
+
+	fmt.Println("Next business day:", cal.NextBusinessDay(christmasEve).Format("Mon 2006-01-02"))
+	fmt.Println("Previous business day:", cal.PrevBusinessDay(christmasEve).Format("Mon 2006-01-02"))
+	fmt.Println("+5 business days:", cal.AddBusinessDays(christmasEve, 5).Format("Mon 2006-01-02"))
+	fmt.Println("-3 business days:", cal.AddBusinessDays(christmasEve, -3).Format("Mon 2006-01-02"))
+
+	newYear := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fmt.Println("Business days until new year:", cal.BusinessDaysBetween(christmasEve, newYear))
+
+	fmt.Println("ISO week:", ISOWeek(christmasEve), ISOWeekDate(christmasEve))
+
+	// A Sunday-first calendar with a Friday/Saturday weekend.
+	cal.WeekStart = Sunday
+	cal.Weekend = map[Weekday]bool{Friday: true, Saturday: true}
+	fmt.Println("Sunday-first week:", cal.WeekDays())
+	fmt.Println("Start of week:", cal.StartOfWeek(christmasEve).Format("Mon 2006-01-02"))
+	fmt.Println("Next business day:", cal.NextBusinessDay(christmasEve).Format("Mon 2006-01-02"))
+}
@@ -0,0 +1,126 @@
+// Code generated by "enum-generator -type=Weekday"; DO NOT EDIT.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the constant name of weekday, or Weekday(n) for unknown values.
+func (v Weekday) String() string {
+	switch v {
+	case Sunday:
+		return "Sunday"
+	case Monday:
+		return "Monday"
+	case Tuesday:
+		return "Tuesday"
+	case Wednesday:
+		return "Wednesday"
+	case Thursday:
+		return "Thursday"
+	case Friday:
+		return "Friday"
+	case Saturday:
+		return "Saturday"
+	}
+	return "Weekday(" + strconv.FormatInt(int64(v), 10) + ")"
+}
+
+// WeekdayValues returns every declared Weekday in declaration order.
+func WeekdayValues() []Weekday {
+	return []Weekday{Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday}
+}
+
+// IsValid reports whether v is one of the declared Weekday constants.
+func (v Weekday) IsValid() bool {
+	switch v {
+	case Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday:
+		return true
+	}
+	return false
+}
+
+// ParseWeekday converts a constant name (case-insensitive) back to a Weekday.
+func ParseWeekday(s string) (Weekday, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "sunday":
+		return Sunday, nil
+	case "monday":
+		return Monday, nil
+	case "tuesday":
+		return Tuesday, nil
+	case "wednesday":
+		return Wednesday, nil
+	case "thursday":
+		return Thursday, nil
+	case "friday":
+		return Friday, nil
+	case "saturday":
+		return Saturday, nil
+	}
+	return 0, fmt.Errorf("invalid Weekday: %q", s)
+}
+
+// MarshalText encodes v as its constant name.
+func (v Weekday) MarshalText() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid Weekday: %d", int64(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a constant name into v.
+func (v *Weekday) UnmarshalText(text []byte) error {
+	parsed, err := ParseWeekday(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON encodes v as a JSON string holding its constant name.
+func (v Weekday) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a JSON string holding a constant name into v.
+func (v *Weekday) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Weekday should be a string, got %s", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Switch calls the function matching v. It takes one function per constant,
+// so adding a constant to Weekday breaks every caller until the new case is handled.
+// Invalid values panic.
+func (v Weekday) Switch(sunday, monday, tuesday, wednesday, thursday, friday, saturday func()) {
+	switch v {
+	case Sunday:
+		sunday()
+	case Monday:
+		monday()
+	case Tuesday:
+		tuesday()
+	case Wednesday:
+		wednesday()
+	case Thursday:
+		thursday()
+	case Friday:
+		friday()
+	case Saturday:
+		saturday()
+	default:
+		panic("Weekday.Switch: invalid value " + v.String())
+	}
+}
@@ -0,0 +1,362 @@
+/*
+	Membership Tier Rules Engine:
+
+		- Silver, Gold and Platinum are iota constants, so their numbers already
+		  give us an order: Silver (1) < Gold (2) < Platinum (3).
+		- Tier 0 is skipped with _, which we use for "no tier yet".
+		- Rules (thresholds, grace periods, benefits) are data, loaded from JSON.
+
+	Transitions:
+
+		Upgrade					applied immediately.
+		Downgrade				starts a grace period (graceDays of the current tier);
+								the customer keeps the tier until it ends.
+		Requalify in grace		cancels the pending downgrade.
+
+	Rules are JSON only: a YAML file would need a third-party parser, and these
+	lessons stick to the standard library.
+
+	Run: go run main.go tier_enum.go transitionkind_enum.go
+*/
+
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+//go:generate go run ../3-enum-generator/main.go -type=Tier,TransitionKind
+
+type Tier int
+
+// Use _ to skip a value: Tier(0) means "no tier".
+const (
+	_        Tier = iota // Skips 0
+	Silver               // 1
+	Gold                 // 2
+	Platinum             // 3
+)
+
+type TransitionKind int
+
+const (
+	Unchanged TransitionKind = iota
+	Upgrade
+	Downgrade
+	GraceStarted
+	GraceCancelled
+)
+
+// CompareTiers orders tiers: negative if a < b, zero if equal, positive if a > b.
+func CompareTiers(a, b Tier) int {
+	return cmp.Compare(a, b)
+}
+
+// Benefits is one row of the benefits table.
+type Benefits struct {
+	DiscountPercent int  `json:"discountPercent"`
+	FreeShipping    bool `json:"freeShipping"`
+	LoungeAccess    bool `json:"loungeAccess"`
+}
+
+// Rule is the threshold and benefits for one tier.
+type Rule struct {
+	Tier            Tier
+	MinSpend        int64 // whole currency units over the last 12 months
+	MinTenureMonths int
+	GraceDays       int
+	Benefits        Benefits
+}
+
+// rawRule is the JSON shape of a Rule. The tier stays a string so a bad name
+// can be reported with the index of the rule it came from.
+type rawRule struct {
+	Tier            string   `json:"tier"`
+	MinSpend        int64    `json:"minSpend"`
+	MinTenureMonths int      `json:"minTenureMonths"`
+	GraceDays       int      `json:"graceDays"`
+	Benefits        Benefits `json:"benefits"`
+}
+
+// RuleError points at the rule that failed validation.
+type RuleError struct {
+	Index int    // position in the "tiers" list
+	Tier  string // tier name as written in the file
+	Field string
+	Msg   string
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("tiers[%d] (%s): %s: %s", e.Index, e.Tier, e.Field, e.Msg)
+}
+
+// Engine computes tiers and transitions from a validated set of rules.
+type Engine struct {
+	rules []Rule // sorted from lowest to highest tier
+}
+
+// LoadRules reads a rules file and validates it.
+func LoadRules(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return engine, nil
+}
+
+// ParseRules decodes and validates JSON rules. Every invalid rule is reported,
+// not just the first one.
+func ParseRules(data []byte) (*Engine, error) {
+	// Each rule is decoded on its own so a bad one can be reported with its index.
+	var file struct {
+		Tiers []json.RawMessage `json:"tiers"`
+	}
+	if err := decodeStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid rules JSON: %w", err)
+	}
+	if len(file.Tiers) == 0 {
+		return nil, errors.New("no tiers defined")
+	}
+
+	var errs []error
+	seen := map[Tier]int{}
+	var rules []Rule
+
+	for i, msg := range file.Tiers {
+		var raw rawRule
+		if err := decodeStrict(msg, &raw); err != nil {
+			errs = append(errs, fmt.Errorf("tiers[%d]: %w", i, err))
+			continue
+		}
+		fail := func(field, format string, args ...any) {
+			errs = append(errs, &RuleError{Index: i, Tier: raw.Tier, Field: field, Msg: fmt.Sprintf(format, args...)})
+		}
+
+		tier, err := ParseTier(raw.Tier)
+		if err != nil {
+			fail("tier", "unknown tier, want one of %v", TierValues())
+			continue
+		}
+		if first, dup := seen[tier]; dup {
+			fail("tier", "duplicate of tiers[%d]", first)
+			continue
+		}
+		seen[tier] = i
+
+		if raw.MinSpend < 0 {
+			fail("minSpend", "must not be negative, got %d", raw.MinSpend)
+		}
+		if raw.MinTenureMonths < 0 {
+			fail("minTenureMonths", "must not be negative, got %d", raw.MinTenureMonths)
+		}
+		if raw.GraceDays < 0 {
+			fail("graceDays", "must not be negative, got %d", raw.GraceDays)
+		}
+		if raw.Benefits.DiscountPercent < 0 || raw.Benefits.DiscountPercent > 100 {
+			fail("benefits.discountPercent", "must be between 0 and 100, got %d", raw.Benefits.DiscountPercent)
+		}
+
+		rules = append(rules, Rule{
+			Tier:            tier,
+			MinSpend:        raw.MinSpend,
+			MinTenureMonths: raw.MinTenureMonths,
+			GraceDays:       raw.GraceDays,
+			Benefits:        raw.Benefits,
+		})
+	}
+
+	// A higher tier must never be easier to reach than a lower one.
+	slices.SortFunc(rules, func(a, b Rule) int { return CompareTiers(a.Tier, b.Tier) })
+	for i := 1; i < len(rules); i++ {
+		lower, higher := rules[i-1], rules[i]
+		index := seen[higher.Tier]
+		if higher.MinSpend < lower.MinSpend {
+			errs = append(errs, &RuleError{Index: index, Tier: higher.Tier.String(), Field: "minSpend",
+				Msg: fmt.Sprintf("%d is lower than %s's %d", higher.MinSpend, lower.Tier, lower.MinSpend)})
+		}
+		if higher.MinTenureMonths < lower.MinTenureMonths {
+			errs = append(errs, &RuleError{Index: index, Tier: higher.Tier.String(), Field: "minTenureMonths",
+				Msg: fmt.Sprintf("%d is lower than %s's %d", higher.MinTenureMonths, lower.Tier, lower.MinTenureMonths)})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return &Engine{rules: rules}, nil
+}
+
+// decodeStrict is json.Unmarshal that also rejects unknown keys, since a
+// misspelled key would otherwise leave its threshold at 0.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		return errors.New("unexpected data after the top-level value")
+	}
+	return nil
+}
+
+// Customer is the input to tier evaluation.
+type Customer struct {
+	ID           string
+	Spend        int64
+	TenureMonths int
+}
+
+// Evaluate returns the highest tier the customer qualifies for, or Tier(0).
+func (e *Engine) Evaluate(c Customer) Tier {
+	var best Tier
+	for _, r := range e.rules {
+		if c.Spend >= r.MinSpend && c.TenureMonths >= r.MinTenureMonths {
+			best = r.Tier
+		}
+	}
+	return best
+}
+
+// Rule returns the rule for a tier.
+func (e *Engine) Rule(t Tier) (Rule, bool) {
+	for _, r := range e.rules {
+		if r.Tier == t {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Benefits returns the benefits of a tier; Tier(0) has none.
+func (e *Engine) Benefits(t Tier) Benefits {
+	r, _ := e.Rule(t)
+	return r.Benefits
+}
+
+// Membership is a customer's current tier, plus any downgrade waiting for its
+// grace period to end.
+type Membership struct {
+	Tier        Tier
+	PendingTier Tier      // tier after the grace period; only set during grace
+	GraceEnds   time.Time // zero when no downgrade is pending
+}
+
+func (m Membership) InGrace() bool {
+	return !m.GraceEnds.IsZero()
+}
+
+// Transition moves a membership to the tier the customer now qualifies for.
+func (e *Engine) Transition(m Membership, c Customer, now time.Time) (Membership, TransitionKind) {
+	qualified := e.Evaluate(c)
+
+	switch {
+	case CompareTiers(qualified, m.Tier) > 0:
+		return Membership{Tier: qualified}, Upgrade
+
+	case CompareTiers(qualified, m.Tier) == 0:
+		if m.InGrace() {
+			return Membership{Tier: m.Tier}, GraceCancelled
+		}
+		return m, Unchanged
+
+	case m.InGrace() && !now.Before(m.GraceEnds):
+		return Membership{Tier: qualified}, Downgrade
+
+	case m.InGrace():
+		// Still in grace; track the latest tier the customer would drop to.
+		m.PendingTier = qualified
+		return m, Unchanged
+
+	default:
+		rule, _ := e.Rule(m.Tier)
+		if rule.GraceDays == 0 {
+			return Membership{Tier: qualified}, Downgrade
+		}
+		return Membership{
+			Tier:        m.Tier,
+			PendingTier: qualified,
+			GraceEnds:   now.AddDate(0, 0, rule.GraceDays),
+		}, GraceStarted
+	}
+}
+
+func main() {
+
+	engine, err := LoadRules("rules.json")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Println("Benefits table:")
+	fmt.Printf("%-10s %-10s %-10s %-10s\n", "Tier", "Discount", "Shipping", "Lounge")
+	for _, t := range TierValues() {
+		b := engine.Benefits(t)
+		fmt.Printf("%-10s %-10s %-10t %-10t\n", t, fmt.Sprintf("%d%%", b.DiscountPercent), b.FreeShipping, b.LoungeAccess)
+	}
+
+	fmt.Println("Gold > Silver?", CompareTiers(Gold, Silver) > 0)
+
+	customers := []Customer{
+		{ID: "c-1", Spend: 500, TenureMonths: 2},
+		{ID: "c-2", Spend: 6000, TenureMonths: 14},
+		{ID: "c-3", Spend: 25000, TenureMonths: 40},
+	}
+	for _, c := range customers {
+		t := engine.Evaluate(c)
+		if !t.IsValid() {
+			fmt.Println(c.ID, "has no tier yet")
+			continue
+		}
+		fmt.Println(c.ID, "->", t)
+	}
+
+	fmt.Println("Transitions:")
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	m := Membership{Tier: Silver}
+	steps := []struct {
+		spend int64
+		days  int
+	}{
+		{spend: 6000, days: 0},   // qualifies for Gold
+		{spend: 1500, days: 10},  // drops to Silver: grace starts
+		{spend: 6000, days: 20},  // back to Gold: grace cancelled
+		{spend: 1500, days: 30},  // grace starts again
+		{spend: 1500, days: 100}, // grace over: downgrade
+	}
+	for _, s := range steps {
+		at := now.AddDate(0, 0, s.days)
+		var kind TransitionKind
+		m, kind = engine.Transition(m, Customer{Spend: s.spend, TenureMonths: 24}, at)
+		fmt.Printf("day %3d spend %5d: %-14s tier=%s", s.days, s.spend, kind, m.Tier)
+		if m.InGrace() {
+			fmt.Printf(" (drops to %s on %s)", m.PendingTier, m.GraceEnds.Format("2006-01-02"))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("Validation errors:")
+	bad := []byte(`{"tiers": [
+		{"tier": "Silver", "minSpend": 1000},
+		{"tier": "Gold", "minSpend": 500, "graceDays": -1},
+		{"tier": "Diamond", "minSpend": 90000},
+		{"tier": "gold", "minSpend": 8000},
+		{"tier": "Platinum", "minSpnd": 20000}
+	]}`)
+	if _, err := ParseRules(bad); err != nil {
+		fmt.Println(err)
+	}
+}
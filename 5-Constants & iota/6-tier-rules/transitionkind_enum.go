@@ -0,0 +1,114 @@
+// Code generated by "enum-generator -type=TransitionKind"; DO NOT EDIT.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns the constant name of transitionKind, or TransitionKind(n) for unknown values.
+func (v TransitionKind) String() string {
+	switch v {
+	case Unchanged:
+		return "Unchanged"
+	case Upgrade:
+		return "Upgrade"
+	case Downgrade:
+		return "Downgrade"
+	case GraceStarted:
+		return "GraceStarted"
+	case GraceCancelled:
+		return "GraceCancelled"
+	}
+	return "TransitionKind(" + strconv.FormatInt(int64(v), 10) + ")"
+}
+
+// TransitionKindValues returns every declared TransitionKind in declaration order.
+func TransitionKindValues() []TransitionKind {
+	return []TransitionKind{Unchanged, Upgrade, Downgrade, GraceStarted, GraceCancelled}
+}
+
+// IsValid reports whether v is one of the declared TransitionKind constants.
+func (v TransitionKind) IsValid() bool {
+	switch v {
+	case Unchanged, Upgrade, Downgrade, GraceStarted, GraceCancelled:
+		return true
+	}
+	return false
+}
+
+// ParseTransitionKind converts a constant name (case-insensitive) back to a TransitionKind.
+func ParseTransitionKind(s string) (TransitionKind, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "unchanged":
+		return Unchanged, nil
+	case "upgrade":
+		return Upgrade, nil
+	case "downgrade":
+		return Downgrade, nil
+	case "gracestarted":
+		return GraceStarted, nil
+	case "gracecancelled":
+		return GraceCancelled, nil
+	}
+	return 0, fmt.Errorf("invalid TransitionKind: %q", s)
+}
+
+// MarshalText encodes v as its constant name.
+func (v TransitionKind) MarshalText() ([]byte, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("invalid TransitionKind: %d", int64(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes a constant name into v.
+func (v *TransitionKind) UnmarshalText(text []byte) error {
+	parsed, err := ParseTransitionKind(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON encodes v as a JSON string holding its constant name.
+func (v TransitionKind) MarshalJSON() ([]byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a JSON string holding a constant name into v.
+func (v *TransitionKind) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("TransitionKind should be a string, got %s", data)
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Switch calls the function matching v. It takes one function per constant,
+// so adding a constant to TransitionKind breaks every caller until the new case is handled.
+// Invalid values panic.
+func (v TransitionKind) Switch(unchanged, upgrade, downgrade, graceStarted, graceCancelled func()) {
+	switch v {
+	case Unchanged:
+		unchanged()
+	case Upgrade:
+		upgrade()
+	case Downgrade:
+		downgrade()
+	case GraceStarted:
+		graceStarted()
+	case GraceCancelled:
+		graceCancelled()
+	default:
+		panic("TransitionKind.Switch: invalid value " + v.String())
+	}
+}
@@ -0,0 +1,324 @@
+/*
+	Encoding Inspector:
+
+		[]byte("GoLang") shows the bytes and rune('🚀') shows the code point, but
+		checking text character by character is easier with a table:
+
+		Offset	Char	Code Point	UTF-8			UTF-16		Category	Name
+		0		G		U+0047		47				0047		Lu			LATIN CAPITAL LETTER G
+		6		🚀		U+1F680		F0 9F 9A 80		D83D DE80	So			ROCKET
+
+		Usage:
+			go run main.go "Hello, 世界 🚀"		inspect the arguments
+			go run main.go -file notes.txt		inspect a file ("-file -" reads stdin)
+			go run main.go -binary "é"			add UTF-8 bytes in binary
+			go run main.go -json "é"			JSON for other tools
+			go run main.go						inspect some sample strings
+
+		Invalid UTF-8 bytes are listed with their byte offsets.
+
+		Note: the standard library has no table of Unicode character names.
+		Names are built for ASCII, Latin-1 letters, Hangul syllables and CJK
+		ideographs, plus a small table of common symbols; others are left blank.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Char describes one rune, or one invalid byte, of the input.
+type Char struct {
+	Offset    int      `json:"offset"`
+	Char      string   `json:"char"`
+	CodePoint string   `json:"code_point"`
+	UTF8      []string `json:"utf8"`
+	UTF8Bin   []string `json:"utf8_binary,omitempty"`
+	UTF16     []string `json:"utf16,omitempty"`
+	Category  string   `json:"category"`
+	Name      string   `json:"name,omitempty"`
+	Invalid   bool     `json:"invalid,omitempty"`
+}
+
+// Report is the result of inspecting one input.
+type Report struct {
+	Source  string `json:"source"`
+	Bytes   int    `json:"bytes"`
+	Runes   int    `json:"runes"`
+	Chars   []Char `json:"chars"`
+	Invalid []int  `json:"invalid_offsets,omitempty"`
+}
+
+// Inspect decodes data rune by rune.
+func Inspect(source string, data []byte, binary bool) Report {
+	r := Report{Source: source, Bytes: len(data)}
+	for off := 0; off < len(data); {
+		ru, size := utf8.DecodeRune(data[off:])
+		raw := data[off : off+size]
+
+		c := Char{Offset: off}
+		for _, b := range raw {
+			c.UTF8 = append(c.UTF8, fmt.Sprintf("%02X", b))
+			if binary {
+				c.UTF8Bin = append(c.UTF8Bin, fmt.Sprintf("%08b", b))
+			}
+		}
+
+		if ru == utf8.RuneError && size == 1 {
+			c.Invalid = true
+			c.Char = fmt.Sprintf(`\x%02X`, raw[0])
+			c.Category = "-"
+			c.Name = invalidReason(data[off:])
+			r.Invalid = append(r.Invalid, off)
+		} else {
+			r.Runes++
+			c.Char = display(ru)
+			c.CodePoint = fmt.Sprintf("U+%04X", ru)
+			for _, u := range utf16.Encode([]rune{ru}) {
+				c.UTF16 = append(c.UTF16, fmt.Sprintf("%04X", u))
+			}
+			c.Category = category(ru)
+			c.Name = name(ru)
+		}
+		r.Chars = append(r.Chars, c)
+		off += size
+	}
+	return r
+}
+
+// invalidReason explains why the bytes at the start of b are not UTF-8.
+func invalidReason(b []byte) string {
+	switch c := b[0]; {
+	case c >= 0x80 && c < 0xC0:
+		return "unexpected continuation byte"
+	case c == 0xC0 || c == 0xC1 || c >= 0xF5:
+		return "byte never used in UTF-8"
+	case !utf8.FullRune(b):
+		return "truncated sequence"
+	}
+	need := 2
+	if b[0] >= 0xF0 {
+		need = 4
+	} else if b[0] >= 0xE0 {
+		need = 3
+	}
+	// Only look at the bytes that are there: b may end (or its capacity go
+	// on) before the sequence does.
+	for _, c := range b[1:min(need, len(b))] {
+		if c < 0x80 || c >= 0xC0 {
+			return "missing continuation byte"
+		}
+	}
+	if len(b) < need {
+		return "truncated sequence"
+	}
+	return "overlong or surrogate encoding"
+}
+
+// display makes control and invisible characters visible in the table.
+func display(r rune) string {
+	switch {
+	case r == ' ':
+		return "␠"
+	case unicode.IsControl(r) || unicode.Is(unicode.Cf, r):
+		q := strconv.QuoteRuneToASCII(r) // '\n', '\u200d'
+		return q[1 : len(q)-1]
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		return "◌" + string(r) // a combining mark needs something to sit on
+	}
+	return string(r)
+}
+
+// categoryNames are the two-letter general categories ("Lu", "Nd", ...),
+// sorted so the lookup is deterministic.
+var categoryNames = func() []string {
+	var names []string
+	for name := range unicode.Categories {
+		if len(name) == 2 && name != "LC" { // LC is Lu+Ll+Lt combined
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}()
+
+func category(r rune) string {
+	for _, name := range categoryNames {
+		if unicode.Is(unicode.Categories[name], r) {
+			return name
+		}
+	}
+	return "Cn" // unassigned
+}
+
+// names covers common characters that can't be derived from a rule.
+var names = map[rune]string{
+	' ': "SPACE", '!': "EXCLAMATION MARK", '"': "QUOTATION MARK", '#': "NUMBER SIGN",
+	'$': "DOLLAR SIGN", '%': "PERCENT SIGN", '&': "AMPERSAND", '\'': "APOSTROPHE",
+	'(': "LEFT PARENTHESIS", ')': "RIGHT PARENTHESIS", '*': "ASTERISK", '+': "PLUS SIGN",
+	',': "COMMA", '-': "HYPHEN-MINUS", '.': "FULL STOP", '/': "SOLIDUS",
+	':': "COLON", ';': "SEMICOLON", '<': "LESS-THAN SIGN", '=': "EQUALS SIGN",
+	'>': "GREATER-THAN SIGN", '?': "QUESTION MARK", '@': "COMMERCIAL AT",
+	'[': "LEFT SQUARE BRACKET", '\\': "REVERSE SOLIDUS", ']': "RIGHT SQUARE BRACKET",
+	'^': "CIRCUMFLEX ACCENT", '_': "LOW LINE", '`': "GRAVE ACCENT",
+	'{': "LEFT CURLY BRACKET", '|': "VERTICAL LINE", '}': "RIGHT CURLY BRACKET", '~': "TILDE",
+	'\t': "CHARACTER TABULATION", '\n': "LINE FEED", '\r': "CARRIAGE RETURN", 0: "NULL",
+	0xA0: "NO-BREAK SPACE", 'ß': "LATIN SMALL LETTER SHARP S", '©': "COPYRIGHT SIGN",
+	'°': "DEGREE SIGN", '€': "EURO SIGN", '£': "POUND SIGN", '¥': "YEN SIGN", '₹': "INDIAN RUPEE SIGN",
+	'…': "HORIZONTAL ELLIPSIS", '–': "EN DASH", '—': "EM DASH", '“': "LEFT DOUBLE QUOTATION MARK",
+	'”': "RIGHT DOUBLE QUOTATION MARK", '•': "BULLET", '✓': "CHECK MARK", '☕': "HOT BEVERAGE",
+	0x0300: "COMBINING GRAVE ACCENT", 0x0301: "COMBINING ACUTE ACCENT", 0x0308: "COMBINING DIAERESIS",
+	0x200B: "ZERO WIDTH SPACE", 0x200D: "ZERO WIDTH JOINER", 0xFE0F: "VARIATION SELECTOR-16",
+	0xFEFF: "ZERO WIDTH NO-BREAK SPACE", 0xFFFD: "REPLACEMENT CHARACTER",
+	'🚀': "ROCKET", '😀': "GRINNING FACE", '👍': "THUMBS UP SIGN", '❤': "HEAVY BLACK HEART",
+	'👩': "WOMAN", '💻': "PERSONAL COMPUTER", '🔥': "FIRE", '🎉': "PARTY POPPER",
+	0x1F3FB: "EMOJI MODIFIER FITZPATRICK TYPE-1-2", 0x1F3FD: "EMOJI MODIFIER FITZPATRICK TYPE-4",
+}
+
+var digitNames = [...]string{"ZERO", "ONE", "TWO", "THREE", "FOUR", "FIVE", "SIX", "SEVEN", "EIGHT", "NINE"}
+
+// latin1Accents names the Latin-1 capital letters; small letters reuse them.
+var latin1Accents = map[rune]string{
+	'À': "A WITH GRAVE", 'Á': "A WITH ACUTE", 'Â': "A WITH CIRCUMFLEX", 'Ã': "A WITH TILDE",
+	'Ä': "A WITH DIAERESIS", 'Å': "A WITH RING ABOVE", 'Æ': "AE", 'Ç': "C WITH CEDILLA",
+	'È': "E WITH GRAVE", 'É': "E WITH ACUTE", 'Ê': "E WITH CIRCUMFLEX", 'Ë': "E WITH DIAERESIS",
+	'Ì': "I WITH GRAVE", 'Í': "I WITH ACUTE", 'Î': "I WITH CIRCUMFLEX", 'Ï': "I WITH DIAERESIS",
+	'Ð': "ETH", 'Ñ': "N WITH TILDE", 'Ò': "O WITH GRAVE", 'Ó': "O WITH ACUTE",
+	'Ô': "O WITH CIRCUMFLEX", 'Õ': "O WITH TILDE", 'Ö': "O WITH DIAERESIS", 'Ø': "O WITH STROKE",
+	'Ù': "U WITH GRAVE", 'Ú': "U WITH ACUTE", 'Û': "U WITH CIRCUMFLEX", 'Ü': "U WITH DIAERESIS",
+	'Ý': "Y WITH ACUTE", 'Þ': "THORN",
+}
+
+// Hangul syllables are named from their jamo: U+AC00 is HANGUL SYLLABLE GA.
+var (
+	hangulLead   = strings.Fields("G GG N D DD R M B BB S SS _ J JJ C K T P H")
+	hangulVowel  = strings.Fields("A AE YA YAE EO E YEO YE O WA WAE OE YO U WEO WE WI YU EU YI I")
+	hangulTrail  = append([]string{""}, strings.Fields("G GG GS N NJ NH D L LG LM LB LS LT LP LH M B BS S SS NG J C K T P H")...)
+	hangulVCount = len(hangulVowel) * len(hangulTrail)
+)
+
+func name(r rune) string {
+	if n, ok := names[r]; ok {
+		return n
+	}
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return "LATIN CAPITAL LETTER " + string(r)
+	case r >= 'a' && r <= 'z':
+		return "LATIN SMALL LETTER " + string(unicode.ToUpper(r))
+	case r >= '0' && r <= '9':
+		return "DIGIT " + digitNames[r-'0']
+	case r >= 0xC0 && r <= 0xFF && r != 0xD7 && r != 0xF7:
+		upper := unicode.ToUpper(r)
+		if r == 'ÿ' {
+			return "LATIN SMALL LETTER Y WITH DIAERESIS"
+		}
+		if upper == r {
+			return "LATIN CAPITAL LETTER " + latin1Accents[r]
+		}
+		return "LATIN SMALL LETTER " + latin1Accents[upper]
+	case r >= 0xAC00 && r <= 0xD7A3:
+		i := int(r - 0xAC00)
+		lead := strings.Trim(hangulLead[i/hangulVCount], "_")
+		vowel := hangulVowel[i%hangulVCount/len(hangulTrail)]
+		return "HANGUL SYLLABLE " + lead + vowel + hangulTrail[i%len(hangulTrail)]
+	case unicode.Is(unicode.Han, r) && unicode.IsLetter(r):
+		return fmt.Sprintf("CJK UNIFIED IDEOGRAPH-%04X", r)
+	case unicode.IsControl(r):
+		return "<control>"
+	}
+	return ""
+}
+
+// PrintTable writes the report as an aligned text table.
+func PrintTable(w io.Writer, r Report) {
+	fmt.Fprintf(w, "%s: %d bytes, %d runes, %d invalid\n", r.Source, r.Bytes, r.Runes, len(r.Invalid))
+	fmt.Fprintf(w, "%-6s  %-5s %-9s %-12s %-10s %-4s %s\n", "Offset", "Char", "CodePoint", "UTF-8", "UTF-16", "Cat", "Name")
+	for _, c := range r.Chars {
+		fmt.Fprintf(w, "%-6d  %s %-9s %-12s %-10s %-4s %s\n",
+			c.Offset, padChar(c.Char, 5), c.CodePoint, strings.Join(c.UTF8, " "),
+			strings.Join(c.UTF16, " "), c.Category, c.Name)
+		if len(c.UTF8Bin) > 0 {
+			fmt.Fprintf(w, "%-6s  %s\n", "", strings.Join(c.UTF8Bin, " "))
+		}
+	}
+	if len(r.Invalid) > 0 {
+		fmt.Fprintln(w, "Invalid UTF-8 at byte offsets:", r.Invalid)
+	}
+}
+
+// padChar pads by terminal columns: emoji and CJK take two.
+func padChar(s string, width int) string {
+	w := 0
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == 0x200D, r == 0xFE0F:
+		case r >= 0x1100 && (unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) || r >= 0x1F300):
+			w += 2
+		default:
+			w++
+		}
+	}
+	return s + strings.Repeat(" ", max(width-w, 1))
+}
+
+func main() {
+	file := flag.String("file", "", `file to inspect ("-" for stdin)`)
+	asJSON := flag.Bool("json", false, "print JSON instead of a table")
+	binary := flag.Bool("binary", false, "show UTF-8 bytes in binary")
+	flag.Parse()
+
+	var reports []Report
+	switch {
+	case *file == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		reports = append(reports, Inspect("stdin", data, *binary))
+	case *file != "":
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+		reports = append(reports, Inspect(*file, data, *binary))
+	case flag.NArg() > 0:
+		for _, arg := range flag.Args() {
+			reports = append(reports, Inspect(fmt.Sprintf("%q", arg), []byte(arg), *binary))
+		}
+	default:
+		// Samples from the type conversion lesson, plus broken bytes.
+		reports = append(reports,
+			Inspect(`"GoLang"`, []byte("GoLang"), *binary),
+			Inspect(`'🚀'`, []byte(string('🚀')), true),
+			Inspect(`"Cafe\u0301 한국 世界"`, []byte("Cafe\u0301 한국 世界"), *binary),
+			Inspect("broken bytes", []byte{'H', 'i', 0xFF, 0xE2, 0x82, ' ', 0x80, 0xC0, 0xAF, 0xED, 0xA0, 0x80}, *binary),
+		)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			fmt.Println("Error:", err)
+		}
+		return
+	}
+	for _, r := range reports {
+		PrintTable(os.Stdout, r)
+		fmt.Println()
+	}
+}
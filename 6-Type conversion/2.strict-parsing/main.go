@@ -0,0 +1,399 @@
+/*
+	Strict Number Parsing:
+
+		strconv.Atoi("45t") fails with: strconv.Atoi: parsing "45t": invalid syntax
+		It doesn't say where the problem is, and int8(x) silently wraps (300 -> 44).
+
+		Parse[T] works for every int, uint and float type:
+
+		Parse[int8]("127")			127
+		Parse[int8]("300")			error: out of range for int8 [-128, 127]
+		Parse[int]("45t")			error: unexpected 't' at position 2
+		Parse[float32]("1.5e3")		1500
+
+		ParseWith[T](s, Options{...}) can relax the input:
+
+		Option			Accepts
+		Trim			"  42  "
+		Underscores		"1_000_000" (like Go literals)
+		Separator		"1,000,000" (groups of three, any separator rune)
+		Decimal			"3,14" (decimal mark other than '.')
+
+		Narrowing conversions check the range instead of wrapping:
+
+		Convert[int8](int64(300))		error instead of 44
+		FromFloat[int](2.5, HalfEven)	2 (Truncate, HalfEven, Floor, Ceil)
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+type Number interface {
+	Integer | Float
+}
+
+var (
+	ErrSyntax = errors.New("invalid syntax")
+	ErrRange  = errors.New("value out of range")
+)
+
+// SyntaxError points at the first character that could not be parsed.
+type SyntaxError struct {
+	Input string
+	Pos   int  // byte offset in Input
+	Char  rune // offending character, or 0 at end of input
+	Msg   string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Char == 0 {
+		return fmt.Sprintf("parsing %q: %s at end of input", e.Input, e.Msg)
+	}
+	return fmt.Sprintf("parsing %q: %s %q at position %d", e.Input, e.Msg, e.Char, e.Pos)
+}
+
+func (e *SyntaxError) Unwrap() error { return ErrSyntax }
+
+// RangeError reports a value that doesn't fit the target type.
+type RangeError struct {
+	Value string
+	Type  string
+	Min   string
+	Max   string
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%s is out of range for %s [%s, %s]", e.Value, e.Type, e.Min, e.Max)
+}
+
+func (e *RangeError) Unwrap() error { return ErrRange }
+
+// Options relax what ParseWith accepts. The zero value is strict.
+type Options struct {
+	Trim        bool // ignore leading and trailing spaces
+	Underscores bool // allow '_' between digits
+	Separator   rune // thousands separator, e.g. ',' (0 means none)
+	Decimal     rune // decimal mark for floats (0 means '.')
+}
+
+// Parse converts s to T, rejecting anything strconv would reject and
+// anything that doesn't fit T.
+func Parse[T Number](s string) (T, error) {
+	return ParseWith[T](s, Options{})
+}
+
+// ParseWith is Parse with relaxed input rules.
+func ParseWith[T Number](s string, opts Options) (T, error) {
+	t := reflect.TypeFor[T]()
+	isFloat := t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+
+	clean, err := normalize(s, opts, isFloat)
+	if err != nil {
+		return 0, err
+	}
+
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(clean, t.Bits())
+		if err != nil {
+			maxFloat := math.MaxFloat64
+			if t.Bits() == 32 {
+				maxFloat = math.MaxFloat32
+			}
+			bound := strconv.FormatFloat(maxFloat, 'g', -1, t.Bits())
+			return 0, convError(err, s, &RangeError{strings.TrimSpace(s), t.String(), "-" + bound, bound})
+		}
+		return T(f), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		// ParseUint takes no sign: "+5" is 5, "-0" is 0, any other
+		// negative number is below the range.
+		digits := strings.TrimPrefix(clean, "+")
+		if rest, negative := strings.CutPrefix(digits, "-"); negative {
+			if strings.Trim(rest, "0") != "" {
+				min, max := limits(t)
+				return 0, &RangeError{clean, t.String(), min, max}
+			}
+			digits = rest
+		}
+		u, err := strconv.ParseUint(digits, 10, t.Bits())
+		if err != nil {
+			min, max := limits(t)
+			return 0, convError(err, s, &RangeError{clean, t.String(), min, max})
+		}
+		return T(u), nil
+	default:
+		i, err := strconv.ParseInt(clean, 10, t.Bits())
+		if err != nil {
+			min, max := limits(t)
+			return 0, convError(err, s, &RangeError{clean, t.String(), min, max})
+		}
+		return T(i), nil
+	}
+}
+
+// convError turns a strconv failure into rangeErr when the value was too
+// big, and into a SyntaxError otherwise. normalize has already checked
+// every character, so the second case means the two disagree.
+func convError(err error, input string, rangeErr *RangeError) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return rangeErr
+	}
+	r, _ := utf8.DecodeRuneInString(input)
+	return &SyntaxError{input, 0, r, "invalid number starting with"}
+}
+
+// normalize checks s character by character and returns the plain form
+// strconv understands ("-1234.5e3"). Because every character is checked
+// here, strconv can only fail on range afterwards.
+func normalize(s string, opts Options, isFloat bool) (string, error) {
+	decimal := opts.Decimal
+	if decimal == 0 {
+		decimal = '.'
+	}
+
+	start, end := 0, len(s)
+	if opts.Trim {
+		// Keep offsets into s so errors point at the original input.
+		start = len(s) - len(strings.TrimLeft(s, " \t"))
+		end = max(len(strings.TrimRight(s, " \t")), start)
+	}
+
+	var out strings.Builder
+	fail := func(pos int, msg string) error {
+		if pos >= end {
+			return &SyntaxError{s, end, 0, msg}
+		}
+		r, _ := utf8.DecodeRuneInString(s[pos:])
+		return &SyntaxError{s, pos, r, msg}
+	}
+
+	i := start
+	if i < end && (s[i] == '+' || s[i] == '-') {
+		out.WriteByte(s[i])
+		i++
+	}
+
+	var (
+		digits     = 0 // digits in the current part
+		group      = 0 // digits since the last thousands separator
+		sawSep     = false
+		inFraction = false
+		inExponent = false
+		prevDigit  = false
+	)
+	for i < end {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r >= '0' && r <= '9':
+			out.WriteRune(r)
+			digits++
+			group++
+			prevDigit = true
+
+		case r == '_' && opts.Underscores:
+			if !prevDigit || i+size >= end || s[i+size] < '0' || s[i+size] > '9' {
+				return "", fail(i, "misplaced underscore")
+			}
+			prevDigit = false
+
+		case r == opts.Separator && opts.Separator != 0 && !inFraction && !inExponent:
+			if !prevDigit || (sawSep && group != 3) || (!sawSep && group > 3) {
+				return "", fail(i, "misplaced thousands separator")
+			}
+			sawSep, group, prevDigit = true, 0, false
+
+		case r == decimal && isFloat && !inFraction && !inExponent:
+			if sawSep && group != 3 {
+				return "", fail(i, "misplaced thousands separator before")
+			}
+			out.WriteByte('.')
+			inFraction, prevDigit = true, false
+
+		case (r == 'e' || r == 'E') && isFloat && !inExponent && digits > 0:
+			if sawSep && group != 3 && !inFraction {
+				return "", fail(i, "misplaced thousands separator before")
+			}
+			out.WriteByte('e')
+			inExponent, digits, prevDigit = true, 0, false
+			if i+1 < end && (s[i+1] == '+' || s[i+1] == '-') {
+				out.WriteByte(s[i+1])
+				i++
+			}
+
+		default:
+			return "", fail(i, "unexpected")
+		}
+		i += size
+	}
+
+	switch {
+	case digits == 0 && inExponent:
+		return "", fail(end, "missing exponent digits")
+	case out.Len() == 0 || strings.Trim(out.String(), "+-.") == "":
+		return "", fail(i, "missing digits")
+	case sawSep && group != 3 && !inFraction && !inExponent:
+		return "", fail(end, "last group needs three digits")
+	}
+	return out.String(), nil
+}
+
+// limits returns the smallest and largest value of integer type t as text.
+func limits(t reflect.Type) (min, max string) {
+	bits := t.Bits()
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "0", strconv.FormatUint(math.MaxUint64>>(64-bits), 10)
+	}
+	return strconv.FormatInt(math.MinInt64>>(64-bits), 10), strconv.FormatInt(math.MaxInt64>>(64-bits), 10)
+}
+
+// Convert changes the integer type of v, failing instead of wrapping
+// when the value doesn't fit: int8(int64(300)) is 44, Convert is an error.
+func Convert[To, From Integer](v From) (To, error) {
+	to := To(v)
+	// A value fits if it survives the round trip and keeps its sign.
+	if From(to) != v || (v < 0) != (to < 0) {
+		min, max := limits(reflect.TypeFor[To]())
+		return 0, &RangeError{fmt.Sprint(v), reflect.TypeFor[To]().String(), min, max}
+	}
+	return to, nil
+}
+
+// RoundingMode picks how FromFloat drops the fraction.
+type RoundingMode int
+
+const (
+	Truncate RoundingMode = iota // toward zero, like int(f)
+	HalfEven                     // to nearest, ties to even (banker's rounding)
+	Floor                        // toward -Inf
+	Ceil                         // toward +Inf
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case Truncate:
+		return "Truncate"
+	case HalfEven:
+		return "HalfEven"
+	case Floor:
+		return "Floor"
+	case Ceil:
+		return "Ceil"
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int(m))
+}
+
+// FromFloat rounds f with mode and converts it to T. NaN, infinities and
+// values outside T's range are errors (int(f) gives an undefined result).
+func FromFloat[T Integer](f float64, mode RoundingMode) (T, error) {
+	var r float64
+	switch mode {
+	case Truncate:
+		r = math.Trunc(f)
+	case HalfEven:
+		r = math.RoundToEven(f)
+	case Floor:
+		r = math.Floor(f)
+	case Ceil:
+		r = math.Ceil(f)
+	default:
+		return 0, fmt.Errorf("unknown rounding mode %v", mode)
+	}
+
+	t := reflect.TypeFor[T]()
+	bits := t.Bits()
+	lo, hi := -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1) // [lo, hi)
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lo, hi = 0, math.Ldexp(1, bits)
+	}
+	if math.IsNaN(r) || r < lo || r >= hi {
+		min, max := limits(t)
+		return 0, &RangeError{strconv.FormatFloat(f, 'g', -1, 64), t.String(), min, max}
+	}
+	return T(r), nil
+}
+
+func main() {
+
+	fmt.Println("strconv.Atoi vs Parse:")
+	if _, err := strconv.Atoi("45t"); err != nil {
+		fmt.Println("  Atoi: ", err)
+	}
+	if _, err := Parse[int]("45t"); err != nil {
+		fmt.Println("  Parse:", err)
+	}
+
+	n8, _ := Parse[int8]("-128")
+	u16, _ := Parse[uint16]("65535")
+	f32, _ := Parse[float32]("1.5e3")
+	fmt.Println("Parsed:", n8, u16, f32)
+
+	for _, bad := range []string{"300", "-1", "", "+", "1e5"} {
+		if _, err := Parse[uint8](bad); err != nil {
+			fmt.Printf("  Parse[uint8](%q): %v (range: %t)\n", bad, err, errors.Is(err, ErrRange))
+		}
+	}
+
+	relaxed := Options{Trim: true, Underscores: true, Separator: ','}
+	for _, s := range []string{"  1,000,000 ", "1_000_000", "12,34", "1,000,", "1__0"} {
+		v, err := ParseWith[int64](s, relaxed)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("ParseWith[int64](%q) = %d\n", s, v)
+	}
+
+	european := Options{Separator: '.', Decimal: ','}
+	price, err := ParseWith[float64]("1.234,56", european)
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("European 1.234,56 =", price)
+
+	big := int64(300)
+	fmt.Println("int8(300) wraps to", int8(big))
+	if _, err := Convert[int8](big); err != nil {
+		fmt.Println("Convert[int8]:", err)
+	}
+	if _, err := Convert[uint](-1); err != nil {
+		fmt.Println("Convert[uint]:", err)
+	}
+	small, _ := Convert[int16](int64(-1200))
+	fmt.Println("Convert[int16](-1200) =", small)
+
+	fmt.Println("Float to int:")
+	for _, f := range []float64{2.5, 3.5, -2.5, 42.99} {
+		fmt.Printf("  %6.2f:", f)
+		for _, mode := range []RoundingMode{Truncate, HalfEven, Floor, Ceil} {
+			v, _ := FromFloat[int](f, mode)
+			fmt.Printf(" %s=%d", mode, v)
+		}
+		fmt.Println()
+	}
+	if _, err := FromFloat[int32](1e10, Truncate); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if _, err := FromFloat[int](math.NaN(), HalfEven); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
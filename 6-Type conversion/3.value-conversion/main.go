@@ -0,0 +1,685 @@
+/*
+	Value Conversion Engine:
+
+		A type switch handles the types you list and nothing else:
+
+		switch v := data.(type) {
+		case int: ...
+		case string: ...
+		default: // float64, time.Time, []string ... all end up here
+		}
+
+		Engine.Convert takes any value and a target type and works out the
+		conversion with reflection:
+
+		From				To					How
+		int, uint, float	any number type		range and precision checked
+		string				number, bool		strconv, with the reason on failure
+		number, bool		string				strconv (not string(65) == "A")
+		string				time.Time			RFC 3339, "2006-01-02 15:04:05", "2006-01-02"
+		int64				time.Time			Unix seconds
+		string				time.Duration		time.ParseDuration ("1h30m")
+		string				[]T					split on commas, then each element
+		[]A, map[K]V		[]B, map[K2]V2		element by element
+		*T					T (and back)		dereference / allocate
+
+		Converters for specific type pairs can be registered, and they win over
+		the built-in rules (e.g. Celsius -> Fahrenheit).
+
+		Errors say what went wrong:
+
+		ErrLossy		the value would change: 3.7 -> int, 300 -> int8, -1 -> uint
+		ErrImpossible	no meaningful conversion: "abc" -> int, map -> bool
+
+		Set AllowLossy to truncate, round and wrap instead of failing on
+		ErrLossy: 3.7 -> 3, 300 -> int8 44, 300.5 -> int8 44, like Go's own
+		integer conversions.
+
+		Run the tests with:
+
+			go test main.go main_test.go
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrLossy      = errors.New("lossy conversion")
+	ErrImpossible = errors.New("impossible conversion")
+)
+
+// ConversionError explains why a value could not be converted.
+type ConversionError struct {
+	Value  any
+	To     reflect.Type
+	Path   string // where inside a slice or map, e.g. `[2]` or `["port"]`
+	Reason string
+	Err    error // ErrLossy or ErrImpossible
+}
+
+func (e *ConversionError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("cannot convert %s at %s to %s: %v: %s", describe(e.Value), e.Path, e.To, e.Err, e.Reason)
+	}
+	return fmt.Sprintf("cannot convert %s to %s: %v: %s", describe(e.Value), e.To, e.Err, e.Reason)
+}
+
+func (e *ConversionError) Unwrap() error { return e.Err }
+
+func describe(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%T(%v)", v, v)
+}
+
+// ConvertFunc converts a value of a registered source type.
+type ConvertFunc func(v any) (any, error)
+
+type typePair struct {
+	from, to reflect.Type
+}
+
+// Engine converts values between types.
+type Engine struct {
+	AllowLossy  bool     // truncate/round instead of returning ErrLossy
+	TimeLayouts []string // layouts tried when parsing a time.Time; the first one formats
+	converters  map[typePair]ConvertFunc
+}
+
+var defaultTimeLayouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// NewEngine returns an engine with the built-in rules.
+func NewEngine() *Engine {
+	return &Engine{
+		TimeLayouts: slices.Clone(defaultTimeLayouts),
+		converters:  map[typePair]ConvertFunc{},
+	}
+}
+
+// timeLayouts falls back to the defaults when TimeLayouts was emptied.
+func (e *Engine) timeLayouts() []string {
+	if len(e.TimeLayouts) == 0 {
+		return defaultTimeLayouts
+	}
+	return e.TimeLayouts
+}
+
+// Register adds a converter for an exact pair of types.
+func (e *Engine) Register(from, to reflect.Type, fn ConvertFunc) {
+	e.converters[typePair{from, to}] = fn
+}
+
+// RegisterFunc is a typed Register: RegisterFunc(e, func(c Celsius) (Fahrenheit, error) {...}).
+func RegisterFunc[From, To any](e *Engine, fn func(From) (To, error)) {
+	e.Register(reflect.TypeFor[From](), reflect.TypeFor[To](), func(v any) (any, error) {
+		return fn(v.(From))
+	})
+}
+
+// ConvertTo converts v to T.
+func ConvertTo[T any](e *Engine, v any) (T, error) {
+	var zero T
+	out, err := e.Convert(v, reflect.TypeFor[T]())
+	if err != nil {
+		return zero, err
+	}
+	// nil converted to an interface type (ConvertTo[any](e, nil)) is nil.
+	t, ok := out.(T)
+	if !ok {
+		return zero, nil
+	}
+	return t, nil
+}
+
+// Convert converts v to type to.
+func (e *Engine) Convert(v any, to reflect.Type) (any, error) {
+	out, err := e.convert(reflect.ValueOf(v), to, "")
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+func (e *Engine) convert(v reflect.Value, to reflect.Type, path string) (reflect.Value, error) {
+	fail := func(err error, format string, args ...any) (reflect.Value, error) {
+		var value any
+		if v.IsValid() {
+			value = v.Interface()
+		}
+		return reflect.Value{}, &ConversionError{value, to, path, fmt.Sprintf(format, args...), err}
+	}
+
+	if !v.IsValid() {
+		switch to.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			return reflect.Zero(to), nil
+		}
+		return fail(ErrImpossible, "nil has no %s value", to)
+	}
+	from := v.Type()
+
+	if fn, ok := e.converters[typePair{from, to}]; ok {
+		out, err := fn(v.Interface())
+		if err != nil {
+			return fail(ErrImpossible, "%v", err)
+		}
+		// The converter is user code: check what it returned before
+		// handing it on as a value of type to.
+		rv := reflect.ValueOf(out)
+		if !rv.IsValid() {
+			return fail(ErrImpossible, "the registered converter returned nil")
+		}
+		if !rv.Type().AssignableTo(to) {
+			return fail(ErrImpossible, "the registered converter returned a value of type %s", rv.Type())
+		}
+		result := reflect.New(to).Elem()
+		result.Set(rv)
+		return result, nil
+	}
+	if from == to {
+		return v, nil
+	}
+	if to.Kind() == reflect.Interface {
+		if from.Implements(to) {
+			out := reflect.New(to).Elem()
+			out.Set(v)
+			return out, nil
+		}
+		return fail(ErrImpossible, "%s does not implement %s", from, to)
+	}
+
+	// Unwrap interfaces and pointers on the way in, allocate on the way out.
+	switch {
+	case from.Kind() == reflect.Interface:
+		return e.convert(v.Elem(), to, path)
+	case from.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return e.convert(reflect.Value{}, to, path)
+		}
+		return e.convert(v.Elem(), to, path)
+	case to.Kind() == reflect.Pointer:
+		elem, err := e.convert(v, to.Elem(), path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(to.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	switch {
+	case to == timeType:
+		return e.toTime(v, fail)
+	case from == timeType:
+		switch {
+		case to.Kind() == reflect.String:
+			return reflect.ValueOf(v.Interface().(time.Time).Format(e.timeLayouts()[0])).Convert(to), nil
+		case isInt(to):
+			return e.convert(reflect.ValueOf(v.Interface().(time.Time).Unix()), to, path)
+		}
+		return fail(ErrImpossible, "a time converts to a string or Unix seconds only")
+	case to == durationType && from.Kind() == reflect.String:
+		d, err := time.ParseDuration(v.String())
+		if err != nil {
+			return fail(ErrImpossible, `expected a duration like "1h30m"`)
+		}
+		return reflect.ValueOf(d), nil
+	case from == durationType && to.Kind() == reflect.String:
+		return reflect.ValueOf(v.Interface().(time.Duration).String()).Convert(to), nil
+	}
+
+	switch to.Kind() {
+	case reflect.Bool:
+		return e.toBool(v, to, fail)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.toInt(v, to, fail)
+	case reflect.Float32, reflect.Float64:
+		return e.toFloat(v, to, fail)
+	case reflect.Complex64, reflect.Complex128:
+		if f, ok := numberValue(v); ok {
+			return reflect.ValueOf(complex(f, 0)).Convert(to), nil
+		}
+		if isComplex(from) {
+			return v.Convert(to), nil
+		}
+	case reflect.String:
+		return e.toString(v, to, fail)
+	case reflect.Slice, reflect.Array:
+		return e.toList(v, to, path, fail)
+	case reflect.Map:
+		return e.toMap(v, to, path, fail)
+	case reflect.Struct:
+		if from.ConvertibleTo(to) {
+			return v.Convert(to), nil // same fields, different named type
+		}
+	}
+	return fail(ErrImpossible, "no rule converts %s to %s", from.Kind(), to.Kind())
+}
+
+type failFunc func(err error, format string, args ...any) (reflect.Value, error)
+
+func isInt(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+func isComplex(t reflect.Type) bool {
+	return t.Kind() == reflect.Complex64 || t.Kind() == reflect.Complex128
+}
+
+// numberValue returns a real number's value as float64 (exact for ints up to 2^53).
+func numberValue(v reflect.Value) (float64, bool) {
+	switch {
+	case isInt(v.Type()):
+		return float64(v.Int()), true
+	case isUint(v.Type()):
+		return float64(v.Uint()), true
+	case isFloat(v.Type()):
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func (e *Engine) toBool(v reflect.Value, to reflect.Type, fail failFunc) (reflect.Value, error) {
+	var b bool
+	switch {
+	case v.Kind() == reflect.String:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v.String()))
+		if err != nil {
+			return fail(ErrImpossible, "expected true/false, 1/0 or t/f")
+		}
+		b = parsed
+	case v.Kind() == reflect.Bool:
+		b = v.Bool()
+	default:
+		f, ok := numberValue(v)
+		if !ok {
+			return fail(ErrImpossible, "%s has no truth value", v.Kind())
+		}
+		if f != 0 && f != 1 && !e.AllowLossy {
+			return fail(ErrLossy, "only 0 and 1 map to false and true")
+		}
+		b = f != 0
+	}
+	return reflect.ValueOf(b).Convert(to), nil
+}
+
+func (e *Engine) toInt(v reflect.Value, to reflect.Type, fail failFunc) (reflect.Value, error) {
+	out := reflect.New(to).Elem()
+	bits := to.Bits()
+
+	switch {
+	case isInt(v.Type()) || isUint(v.Type()):
+		// Compare in the wider of the two domains so nothing wraps.
+		negative := isInt(v.Type()) && v.Int() < 0
+		if negative && isUint(to) {
+			if !e.AllowLossy {
+				return fail(ErrLossy, "%s cannot hold negative numbers", to)
+			}
+			out.SetUint(uint64(v.Int()))
+			return out, nil
+		}
+		if negative {
+			if out.OverflowInt(v.Int()) && !e.AllowLossy {
+				return fail(ErrLossy, "below the minimum of %s (%d)", to, int64(-1)<<(bits-1))
+			}
+			out.SetInt(v.Int())
+			return out, nil
+		}
+		var u uint64
+		if isInt(v.Type()) {
+			u = uint64(v.Int())
+		} else {
+			u = v.Uint()
+		}
+		max := uint64(math.MaxUint64) >> (64 - bits)
+		if isInt(to) {
+			max >>= 1
+		}
+		if u > max && !e.AllowLossy {
+			return fail(ErrLossy, "above the maximum of %s (%d)", to, max)
+		}
+		if isInt(to) {
+			out.SetInt(int64(u))
+		} else {
+			out.SetUint(u)
+		}
+		return out, nil
+
+	case isFloat(v.Type()):
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fail(ErrImpossible, "%v is not a number", f)
+		}
+		if f != math.Trunc(f) && !e.AllowLossy {
+			_, fraction, _ := strings.Cut(strconv.FormatFloat(f, 'f', -1, 64), ".")
+			return fail(ErrLossy, "the fraction .%s would be dropped", fraction)
+		}
+		lo, hi := -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+		if isUint(to) {
+			lo, hi = 0, math.Ldexp(1, bits)
+		}
+		if f < lo || f >= hi {
+			if !e.AllowLossy {
+				return fail(ErrLossy, "outside the range of %s", to)
+			}
+			// Wrap like the integer conversions do: keep the low 64 bits
+			// of the truncated value and let SetInt/SetUint keep fewer.
+			m := math.Mod(math.Trunc(f), math.Ldexp(1, 64)) // in (-2⁶⁴, 2⁶⁴)
+			var u uint64
+			switch {
+			case m >= 0:
+				u = uint64(m)
+			case m >= -math.Ldexp(1, 63):
+				u = uint64(int64(m)) // two's complement, as int -> uint does
+			default:
+				u = uint64(m + math.Ldexp(1, 64)) // exact: both within a factor of 2
+			}
+			if isInt(to) {
+				out.SetInt(int64(u))
+			} else {
+				out.SetUint(u)
+			}
+			return out, nil
+		}
+		if isInt(to) {
+			out.SetInt(int64(f))
+		} else {
+			out.SetUint(uint64(f))
+		}
+		return out, nil
+
+	case isComplex(v.Type()):
+		c := v.Complex()
+		if imag(c) != 0 && !e.AllowLossy {
+			return fail(ErrLossy, "the imaginary part %g would be dropped", imag(c))
+		}
+		return e.toInt(reflect.ValueOf(real(c)), to, fail)
+
+	case v.Kind() == reflect.Bool:
+		switch {
+		case !v.Bool():
+		case isInt(to):
+			out.SetInt(1)
+		default:
+			out.SetUint(1)
+		}
+		return out, nil
+
+	case v.Kind() == reflect.String:
+		// Base 10 only: user data like "010" means ten, not octal eight.
+		s := strings.TrimSpace(v.String())
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return e.toInt(reflect.ValueOf(i), to, fail)
+		}
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return e.toInt(reflect.ValueOf(u), to, fail)
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return e.toInt(reflect.ValueOf(f), to, fail)
+		}
+		return fail(ErrImpossible, "not a number")
+	}
+	return fail(ErrImpossible, "%s has no numeric value", v.Kind())
+}
+
+func (e *Engine) toFloat(v reflect.Value, to reflect.Type, fail failFunc) (reflect.Value, error) {
+	out := reflect.New(to).Elem()
+	var f float64
+
+	switch {
+	case isInt(v.Type()):
+		i := v.Int()
+		f = float64(i)
+		if (f >= math.Ldexp(1, 63) || int64(f) != i) && !e.AllowLossy {
+			return fail(ErrLossy, "%d has more digits than a float64 can hold (rounds to %.0f)", i, f)
+		}
+	case isUint(v.Type()):
+		u := v.Uint()
+		f = float64(u)
+		if (f >= math.Ldexp(1, 64) || uint64(f) != u) && !e.AllowLossy {
+			return fail(ErrLossy, "%d has more digits than a float64 can hold (rounds to %.0f)", u, f)
+		}
+	case isFloat(v.Type()):
+		f = v.Float()
+	case isComplex(v.Type()):
+		c := v.Complex()
+		if imag(c) != 0 && !e.AllowLossy {
+			return fail(ErrLossy, "the imaginary part %g would be dropped", imag(c))
+		}
+		f = real(c)
+	case v.Kind() == reflect.Bool:
+		if v.Bool() {
+			f = 1
+		}
+	case v.Kind() == reflect.String:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+		if err != nil {
+			return fail(ErrImpossible, "not a number")
+		}
+		f = parsed
+	default:
+		return fail(ErrImpossible, "%s has no numeric value", v.Kind())
+	}
+
+	if to.Kind() == reflect.Float32 && !math.IsNaN(f) && !e.AllowLossy {
+		if math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+			return fail(ErrLossy, "too large for float32")
+		}
+		if float64(float32(f)) != f {
+			return fail(ErrLossy, "float32 would round it to %s", strconv.FormatFloat(float64(float32(f)), 'g', -1, 64))
+		}
+	}
+	out.SetFloat(f)
+	return out, nil
+}
+
+func (e *Engine) toString(v reflect.Value, to reflect.Type, fail failFunc) (reflect.Value, error) {
+	var s string
+	switch {
+	case isInt(v.Type()):
+		s = strconv.FormatInt(v.Int(), 10) // not string(rune(i))
+	case isUint(v.Type()):
+		s = strconv.FormatUint(v.Uint(), 10)
+	case isFloat(v.Type()):
+		s = strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case isComplex(v.Type()):
+		s = strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())
+	case v.Kind() == reflect.Bool:
+		s = strconv.FormatBool(v.Bool())
+	case v.Kind() == reflect.String:
+		s = v.String()
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		s = string(v.Bytes())
+	default:
+		if str, ok := v.Interface().(fmt.Stringer); ok {
+			s = str.String()
+			break
+		}
+		return fail(ErrImpossible, "%s has no text form (use a registered converter)", v.Kind())
+	}
+	return reflect.ValueOf(s).Convert(to), nil
+}
+
+func (e *Engine) toTime(v reflect.Value, fail failFunc) (reflect.Value, error) {
+	switch {
+	case v.Kind() == reflect.String:
+		s := strings.TrimSpace(v.String())
+		for _, layout := range e.timeLayouts() {
+			if t, err := time.Parse(layout, s); err == nil {
+				return reflect.ValueOf(t), nil
+			}
+		}
+		return fail(ErrImpossible, "matches none of the layouts %q", e.timeLayouts())
+	case isInt(v.Type()):
+		return reflect.ValueOf(time.Unix(v.Int(), 0).UTC()), nil
+	}
+	return fail(ErrImpossible, "a time comes from a string or Unix seconds only")
+}
+
+func (e *Engine) toList(v reflect.Value, to reflect.Type, path string, fail failFunc) (reflect.Value, error) {
+	if v.Kind() == reflect.String {
+		if to.Kind() == reflect.Slice && to.Elem().Kind() == reflect.Uint8 {
+			return reflect.ValueOf([]byte(v.String())).Convert(to), nil
+		}
+		// "1, 2, 3" -> []int{1, 2, 3}
+		parts := strings.Split(v.String(), ",")
+		if strings.TrimSpace(v.String()) == "" {
+			parts = nil
+		}
+		v = reflect.ValueOf(parts)
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fail(ErrImpossible, "%s is not a list", v.Kind())
+	}
+
+	var out reflect.Value
+	if to.Kind() == reflect.Array {
+		if v.Len() != to.Len() {
+			return fail(ErrImpossible, "has %d elements, %s needs exactly %d", v.Len(), to, to.Len())
+		}
+		out = reflect.New(to).Elem()
+	} else {
+		out = reflect.MakeSlice(to, v.Len(), v.Len())
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if s, ok := elem.Interface().(string); ok {
+			elem = reflect.ValueOf(strings.TrimSpace(s))
+		}
+		converted, err := e.convert(elem, to.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Index(i).Set(converted)
+	}
+	return out, nil
+}
+
+func (e *Engine) toMap(v reflect.Value, to reflect.Type, path string, fail failFunc) (reflect.Value, error) {
+	if v.Kind() != reflect.Map {
+		return fail(ErrImpossible, "%s is not a map", v.Kind())
+	}
+	out := reflect.MakeMapWithSize(to, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		where := fmt.Sprintf("%s[%#v]", path, iter.Key().Interface())
+		key, err := e.convert(iter.Key(), to.Key(), where)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		value, err := e.convert(iter.Value(), to.Elem(), where)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetMapIndex(key, value)
+	}
+	return out, nil
+}
+
+type Celsius float64
+type Fahrenheit float64
+
+func main() {
+
+	var data interface{} = 56.89
+	engine := NewEngine()
+
+	// The type switch prints "Unknow type" for a float64; the engine converts it.
+	for _, to := range []reflect.Type{
+		reflect.TypeFor[string](),
+		reflect.TypeFor[float32](),
+		reflect.TypeFor[int](),
+		reflect.TypeFor[bool](),
+	} {
+		out, err := engine.Convert(data, to)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("%v -> %s: %#v\n", data, to, out)
+	}
+
+	examples := []struct {
+		value any
+		to    reflect.Type
+	}{
+		{"42", reflect.TypeFor[int8]()},
+		{"010", reflect.TypeFor[uint16]()},
+		{300, reflect.TypeFor[int8]()},
+		{-1, reflect.TypeFor[uint]()},
+		{int64(1<<53 + 1), reflect.TypeFor[float64]()},
+		{"abc", reflect.TypeFor[int]()},
+		{3 + 4i, reflect.TypeFor[float64]()},
+		{"2024-03-01", reflect.TypeFor[time.Time]()},
+		{int64(1700000000), reflect.TypeFor[time.Time]()},
+		{"1h30m", reflect.TypeFor[time.Duration]()},
+		{90 * time.Second, reflect.TypeFor[string]()},
+		{"1, 2, 3", reflect.TypeFor[[]int]()},
+		{[]any{"1", 2.0, true}, reflect.TypeFor[[]float64]()},
+		{[]string{"1", "2.5"}, reflect.TypeFor[[2]int]()},
+		{map[string]string{"port": "8080", "workers": "4"}, reflect.TypeFor[map[string]int]()},
+		{map[string]any{"debug": "true", "verbose": 0}, reflect.TypeFor[map[string]bool]()},
+		{map[string]any{"port": "80.80"}, reflect.TypeFor[map[string]int]()},
+		{"7", reflect.TypeFor[*int]()},
+		{map[string]int{}, reflect.TypeFor[bool]()},
+		{nil, reflect.TypeFor[int]()},
+	}
+	for _, ex := range examples {
+		out, err := engine.Convert(ex.value, ex.to)
+		if err != nil {
+			fmt.Printf("Error: %v (lossy: %t)\n", err, errors.Is(err, ErrLossy))
+			continue
+		}
+		if p, ok := out.(*int); ok {
+			out = fmt.Sprintf("&%d", *p)
+		}
+		fmt.Printf("%s -> %s: %v\n", describe(ex.value), ex.to, out)
+	}
+
+	// A registered converter wins over the float64 -> float64 kind rule.
+	RegisterFunc(engine, func(c Celsius) (Fahrenheit, error) {
+		return Fahrenheit(c*9/5 + 32), nil
+	})
+	f, _ := ConvertTo[Fahrenheit](engine, Celsius(100))
+	fmt.Println("100°C =", f, "°F")
+
+	engine.AllowLossy = true
+	n, _ := ConvertTo[int8](engine, 3.99)
+	fmt.Println("AllowLossy: 3.99 -> int8:", n)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConvert(t *testing.T) {
+	e := NewEngine()
+	tests := []struct {
+		in   any
+		to   reflect.Type
+		want any
+	}{
+		{true, reflect.TypeFor[uint](), uint(1)},
+		{true, reflect.TypeFor[uint8](), uint8(1)},
+		{false, reflect.TypeFor[uint8](), uint8(0)},
+		{true, reflect.TypeFor[int16](), int16(1)},
+		{"010", reflect.TypeFor[int](), 10},
+		{"42", reflect.TypeFor[int8](), int8(42)},
+		{"18446744073709551615", reflect.TypeFor[uint64](), uint64(1<<64 - 1)},
+		{3.0, reflect.TypeFor[uint16](), uint16(3)},
+		{int64(1<<53 - 1), reflect.TypeFor[float64](), float64(1<<53 - 1)},
+		{"true", reflect.TypeFor[bool](), true},
+		{1.5, reflect.TypeFor[string](), "1.5"},
+		{"1, 2, 3", reflect.TypeFor[[]int](), []int{1, 2, 3}},
+		{"1h30m", reflect.TypeFor[time.Duration](), 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := e.Convert(tt.in, tt.to)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Convert(%#v, %s) = %#v, %v; want %#v", tt.in, tt.to, got, err, tt.want)
+		}
+	}
+
+	errs := []struct {
+		in   any
+		to   reflect.Type
+		want error
+	}{
+		{"0x1F", reflect.TypeFor[int](), ErrImpossible},
+		{"abc", reflect.TypeFor[int](), ErrImpossible},
+		{300, reflect.TypeFor[int8](), ErrLossy},
+		{-1, reflect.TypeFor[uint](), ErrLossy},
+		{3.7, reflect.TypeFor[int](), ErrLossy},
+		{300.0, reflect.TypeFor[int8](), ErrLossy},
+		{1e30, reflect.TypeFor[int64](), ErrLossy},
+		{map[string]int{}, reflect.TypeFor[bool](), ErrImpossible},
+	}
+	for _, tt := range errs {
+		if got, err := e.Convert(tt.in, tt.to); !errors.Is(err, tt.want) {
+			t.Errorf("Convert(%#v, %s) = %#v, %v; want %v", tt.in, tt.to, got, err, tt.want)
+		}
+	}
+}
+
+// AllowLossy wraps out-of-range floats the same way it wraps integers.
+func TestAllowLossy(t *testing.T) {
+	e := NewEngine()
+	e.AllowLossy = true
+	tests := []struct {
+		in   any
+		to   reflect.Type
+		want any
+	}{
+		{3.99, reflect.TypeFor[int8](), int8(3)},
+		{300, reflect.TypeFor[int8](), int8(44)},
+		{300.0, reflect.TypeFor[int8](), int8(44)},
+		{300.5, reflect.TypeFor[int8](), int8(44)},
+		{-1, reflect.TypeFor[uint8](), uint8(255)},
+		{-1.5, reflect.TypeFor[uint8](), uint8(255)},
+		{65536.0 + 7, reflect.TypeFor[uint16](), uint16(7)},
+		{3 + 4i, reflect.TypeFor[int](), 3},
+	}
+	for _, tt := range tests {
+		got, err := e.Convert(tt.in, tt.to)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lossy Convert(%#v, %s) = %#v, %v; want %#v", tt.in, tt.to, got, err, tt.want)
+		}
+	}
+}
+
+func TestConvertTo(t *testing.T) {
+	e := NewEngine()
+	if got, err := ConvertTo[any](e, nil); got != nil || err != nil {
+		t.Errorf("ConvertTo[any](nil) = %#v, %v; want nil, nil", got, err)
+	}
+	if got, err := ConvertTo[error](e, nil); got != nil || err != nil {
+		t.Errorf("ConvertTo[error](nil) = %#v, %v; want nil, nil", got, err)
+	}
+	if got, err := ConvertTo[uint8](e, true); got != 1 || err != nil {
+		t.Errorf("ConvertTo[uint8](true) = %d, %v; want 1", got, err)
+	}
+	if _, err := ConvertTo[int](e, nil); !errors.Is(err, ErrImpossible) {
+		t.Errorf("ConvertTo[int](nil): err = %v, want ErrImpossible", err)
+	}
+}
+
+func TestRegisteredConverters(t *testing.T) {
+	e := NewEngine()
+	e.Register(reflect.TypeFor[bool](), reflect.TypeFor[[]byte](), func(any) (any, error) { return nil, nil })
+	if _, err := ConvertTo[[]byte](e, true); !errors.Is(err, ErrImpossible) {
+		t.Errorf("converter returning nil: err = %v, want ErrImpossible", err)
+	}
+	e.Register(reflect.TypeFor[bool](), reflect.TypeFor[[]byte](), func(any) (any, error) { return 3, nil })
+	if _, err := ConvertTo[[]byte](e, true); !errors.Is(err, ErrImpossible) {
+		t.Errorf("converter returning an int: err = %v, want ErrImpossible", err)
+	}
+
+	e.TimeLayouts = nil
+	if got, err := ConvertTo[string](e, time.Unix(0, 0).UTC()); got != "1970-01-01T00:00:00Z" || err != nil {
+		t.Errorf("time with no layouts = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,307 @@
+/*
+	Radix (Base) Conversion:
+
+		%b, %o, %x only cover bases 2, 8 and 16 for one number at a time.
+		This toolkit formats and parses any integer, including *big.Int, in:
+
+		Base			Digits
+		2 .. 36			0-9 then a-z (strconv / big.Int style)
+		58				Bitcoin alphabet (no 0, O, I, l so it's easy to read aloud)
+		62				0-9 A-Z a-z (short URL ids)
+
+		Option				Example
+		Group: 4			1010_1100			(digit grouping, "_" by default)
+		Width: 8			00000101			(zero padding)
+		Prefix: true		0b, 0o, 0x			(only for bases 2, 8, 16)
+		TwosComplement(-1, 8) -> 255 -> 0b1111_1111
+
+		Parse("0xDEAD_BEEF", 0) reads the prefix to pick the base.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// Options control how a number is written.
+type Options struct {
+	Prefix   bool   // add 0b, 0o or 0x for bases 2, 8, 16
+	Upper    bool   // A-F instead of a-f (bases 11..36)
+	Width    int    // zero-pad the digits to at least Width
+	Group    int    // insert GroupSep every Group digits, counted from the right
+	GroupSep string // defaults to "_"
+}
+
+var prefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+// alphabet returns the digit characters of a base.
+func alphabet(base int) (string, error) {
+	switch {
+	case base == 58:
+		return base58Alphabet, nil
+	case base == 62:
+		return base62Alphabet, nil
+	case base >= 2 && base <= 36:
+		return "0123456789abcdefghijklmnopqrstuvwxyz"[:base], nil
+	}
+	return "", fmt.Errorf("unsupported base %d (want 2..36, 58 or 62)", base)
+}
+
+// FormatInt writes n in the given base.
+func FormatInt(n int64, base int, opts Options) (string, error) {
+	return Format(big.NewInt(n), base, opts)
+}
+
+// Format writes x in the given base.
+func Format(x *big.Int, base int, opts Options) (string, error) {
+	digitSet, err := alphabet(base)
+	if err != nil {
+		return "", err
+	}
+
+	abs := new(big.Int).Abs(x)
+	var digits string
+	if base <= 36 {
+		digits = abs.Text(base)
+	} else {
+		digits = encode(abs, digitSet)
+	}
+
+	if opts.Upper && base <= 36 {
+		digits = strings.ToUpper(digits)
+	}
+	if pad := opts.Width - len(digits); pad > 0 {
+		digits = strings.Repeat(string(digitSet[0]), pad) + digits
+	}
+	if opts.Group > 0 {
+		sep := opts.GroupSep
+		if sep == "" {
+			sep = "_"
+		}
+		digits = group(digits, opts.Group, sep)
+	}
+
+	if opts.Prefix {
+		digits = prefixes[base] + digits
+	}
+	if x.Sign() < 0 {
+		digits = "-" + digits
+	}
+	return digits, nil
+}
+
+// encode writes x using a custom alphabet by repeated division.
+func encode(x *big.Int, digitSet string) string {
+	if x.Sign() == 0 {
+		return string(digitSet[0])
+	}
+	base := big.NewInt(int64(len(digitSet)))
+	n := new(big.Int).Set(x)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.QuoRem(n, base, mod)
+		out = append(out, digitSet[mod.Int64()])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// group inserts sep every size characters, counting from the right.
+func group(digits string, size int, sep string) string {
+	var sb strings.Builder
+	first := len(digits) % size
+	if first == 0 {
+		first = size
+	}
+	sb.WriteString(digits[:first])
+	for i := first; i < len(digits); i += size {
+		sb.WriteString(sep + digits[i:i+size])
+	}
+	return sb.String()
+}
+
+// TwosComplement returns the unsigned bit pattern of x at the given width:
+// TwosComplement(-1, 8) is 255. It fails if x doesn't fit in width signed bits.
+func TwosComplement(x *big.Int, width int) (*big.Int, error) {
+	if width <= 0 {
+		return nil, fmt.Errorf("invalid bit width %d", width)
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(width-1)) // 2^(width-1)
+	minValue := new(big.Int).Neg(limit)
+	if x.Cmp(minValue) < 0 || x.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%s does not fit in %d-bit two's complement", x, width)
+	}
+	if x.Sign() >= 0 {
+		return new(big.Int).Set(x), nil
+	}
+	return new(big.Int).Add(x, new(big.Int).Lsh(limit, 1)), nil
+}
+
+// FromTwosComplement reads a width-bit pattern back as a signed number:
+// FromTwosComplement(255, 8) is -1.
+func FromTwosComplement(pattern *big.Int, width int) (*big.Int, error) {
+	if width <= 0 {
+		return nil, fmt.Errorf("invalid bit width %d", width)
+	}
+	if pattern.Sign() < 0 || pattern.BitLen() > width {
+		return nil, fmt.Errorf("%s is not a %d-bit pattern", pattern, width)
+	}
+	if pattern.Bit(width-1) == 0 {
+		return new(big.Int).Set(pattern), nil
+	}
+	return new(big.Int).Sub(pattern, new(big.Int).Lsh(big.NewInt(1), uint(width))), nil
+}
+
+var ErrSyntax = errors.New("invalid syntax")
+
+// ParseError reports where parsing stopped.
+type ParseError struct {
+	Input  string
+	Offset int // byte offset of the bad character, -1 if the input is empty
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("parse %q: %v", e.Input, e.Err)
+	}
+	return fmt.Sprintf("parse %q: %v at offset %d", e.Input, e.Err, e.Offset)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// Parse reads s in the given base. With base 0 the prefix decides: 0b, 0o, 0x
+// or plain decimal. Single underscores between digits (or right after the
+// prefix) are allowed, as in Go literals.
+func Parse(s string, base int) (*big.Int, error) {
+	input := s
+	offset := 0
+
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+		offset++
+	}
+
+	hadPrefix := false
+	if base == 0 {
+		base = 10
+		if len(s) >= 2 && s[0] == '0' {
+			for b, p := range prefixes {
+				if strings.EqualFold(s[:2], p) {
+					base = b
+					s = s[2:]
+					offset += 2
+					hadPrefix = true
+					break
+				}
+			}
+		}
+	}
+
+	digitSet, err := alphabet(base)
+	if err != nil {
+		return nil, err
+	}
+	caseInsensitive := base <= 36
+
+	if s == "" {
+		return nil, &ParseError{Input: input, Offset: -1, Err: ErrSyntax}
+	}
+
+	n := new(big.Int)
+	b := big.NewInt(int64(base))
+	prevUnderscore := !hadPrefix // "0x_FF" is fine, "_FF" is not
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '_' {
+			if prevUnderscore || i == len(s)-1 {
+				return nil, &ParseError{Input: input, Offset: offset + i, Err: ErrSyntax}
+			}
+			prevUnderscore = true
+			continue
+		}
+		prevUnderscore = false
+
+		if caseInsensitive && c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		d := strings.IndexByte(digitSet, c)
+		if d < 0 {
+			return nil, &ParseError{Input: input, Offset: offset + i,
+				Err: fmt.Errorf("%w: %q is not a base-%d digit", ErrSyntax, s[i], base)}
+		}
+		n.Mul(n, b)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	if negative {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+func must(s string, err error) string {
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	return s
+}
+
+func main() {
+
+	num := int64(456)
+	fmt.Println("Binary:     ", must(FormatInt(num, 2, Options{Prefix: true, Group: 4, Width: 12})))
+	fmt.Println("Octal:      ", must(FormatInt(num, 8, Options{Prefix: true})))
+	fmt.Println("Hexadecimal:", must(FormatInt(num, 16, Options{Prefix: true, Upper: true, Width: 4})))
+	fmt.Println("Base 36:    ", must(FormatInt(num, 36, Options{})))
+
+	deadBeef := big.NewInt(0xDEADBEEF)
+	fmt.Println("Grouped hex:", must(Format(deadBeef, 16, Options{Prefix: true, Upper: true, Group: 4})))
+	fmt.Println("Base58:     ", must(Format(deadBeef, 58, Options{})))
+	fmt.Println("Base62:     ", must(Format(deadBeef, 62, Options{})))
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	fmt.Println("big.Int in base 62:", must(Format(huge, 62, Options{})))
+	fmt.Println("big.Int grouped:   ", must(Format(huge, 10, Options{Group: 3, GroupSep: ","})))
+
+	for _, n := range []int64{-1, -128, 127} {
+		pattern, err := TwosComplement(big.NewInt(n), 8)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		back, _ := FromTwosComplement(pattern, 8)
+		fmt.Printf("%4d as int8 bits: %s (reads back as %s)\n",
+			n, must(Format(pattern, 2, Options{Prefix: true, Width: 8, Group: 4})), back)
+	}
+	if _, err := TwosComplement(big.NewInt(200), 8); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	for _, s := range []string{"0b1010_1100", "0o777", "0xDEAD_BEEF", "-42", "0x_FF", "1__0", "12a"} {
+		n, err := Parse(s, 0)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("Parse(%q) = %s\n", s, n)
+	}
+
+	id, _ := Format(deadBeef, 58, Options{})
+	back, err := Parse(id, 58)
+	fmt.Println("Base58 round trip:", back, err)
+}
@@ -0,0 +1,561 @@
+/*
+	Humanized Formatting:
+
+		Raw value				Human form
+		1536000 bytes			1.5 MB (SI, 1000)  /  1.5 MiB (IEC, 1024)
+		7380 seconds			2h 3m
+		now - 72h				3 days ago
+		1234567					1.2M  /  1,234,567
+		21						21st
+
+	Every formatter has an inverse parser where it makes sense, and the types
+	Bytes, Duration, Count and Ordinal implement fmt.Formatter, so they work
+	with Printf verbs and flags:
+
+		Verb / flag		Bytes			Duration		Count			Ordinal
+		%v, %s			1.5 MB			2h 3m			1.2M			21st
+		%#v				1.5 MiB (IEC)	2h 3m 0s		1,234,567		21st
+		%.2v			1.54 MB			-				1.23M			-
+		%d				1536000			7380000000000	1234567			21
+		%10v, %-10v		padded to 10 columns
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ---- Byte sizes ----
+
+type Bytes int64
+
+var (
+	siUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	iecUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+// FormatBytes writes n with SI (1000) or IEC (1024) units and prec decimals.
+func FormatBytes(n int64, iec bool, prec int) string {
+	base, units := 1000.0, siUnits
+	if iec {
+		base, units = 1024.0, iecUnits
+	}
+
+	sign := ""
+	value := float64(n)
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	if value < base {
+		return fmt.Sprintf("%s%d %s", sign, int64(value), units[0])
+	}
+
+	exp := 0
+	for value >= base && exp < len(units)-1 {
+		value /= base
+		exp++
+	}
+	// 999_999 bytes rounds to "1000 kB"; move to the next unit instead.
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'f', prec, 64), 64)
+	if rounded >= base && exp < len(units)-1 {
+		value /= base
+		exp++
+	}
+	s := strconv.FormatFloat(value, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return sign + s + " " + units[exp]
+}
+
+// ParseBytes reads "1.5 MB", "1.5MiB", "2048" or "10 kb" back into bytes.
+func ParseBytes(s string) (int64, error) {
+	number, unit := splitNumber(s)
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse size %q: bad number", s)
+	}
+
+	unit = strings.ToLower(unit)
+	multiplier := 1.0
+	switch {
+	case unit == "" || unit == "b":
+	case strings.HasSuffix(unit, "ib"):
+		i := indexFold(iecUnits, unit)
+		if i < 0 {
+			return 0, fmt.Errorf("parse size %q: unknown unit %q", s, unit)
+		}
+		multiplier = math.Pow(1024, float64(i))
+	default:
+		i := indexFold(siUnits, strings.TrimSuffix(unit, "b")+"b")
+		if i < 0 {
+			return 0, fmt.Errorf("parse size %q: unknown unit %q", s, unit)
+		}
+		multiplier = math.Pow(1000, float64(i))
+	}
+
+	result := value * multiplier
+	if math.Abs(result) > math.MaxInt64 {
+		return 0, fmt.Errorf("parse size %q: too large", s)
+	}
+	return int64(math.Round(result)), nil
+}
+
+func (b Bytes) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), int64(b))
+	case 'v', 's':
+		prec, ok := f.Precision()
+		if !ok {
+			prec = 1
+		}
+		writePadded(f, FormatBytes(int64(b), f.Flag('#'), prec))
+	default:
+		fmt.Fprintf(f, "%%!%c(Bytes=%d)", verb, int64(b))
+	}
+}
+
+// ---- Durations ----
+
+type Duration time.Duration
+
+var durationUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+}
+
+// FormatDuration writes d as "2h 3m". maxUnits limits how many units are
+// shown (0 means all); zero units are skipped unless keepZero is set.
+func FormatDuration(d time.Duration, maxUnits int, keepZero bool) string {
+	if d == 0 {
+		return "0s"
+	}
+	// Work on the unsigned magnitude: -math.MinInt64 does not fit in an int64.
+	sign, rest := "", uint64(d)
+	if d < 0 {
+		sign, rest = "-", -rest
+	}
+
+	// Milliseconds only matter for durations under a minute.
+	showMillis := rest < uint64(time.Minute)
+
+	var parts []string
+	for _, u := range durationUnits {
+		if maxUnits > 0 && len(parts) == maxUnits {
+			break
+		}
+		if u.name == "ms" && !showMillis {
+			break
+		}
+		n := rest / uint64(u.size)
+		rest -= n * uint64(u.size)
+		if n == 0 && (!keepZero || len(parts) == 0) {
+			continue
+		}
+		parts = append(parts, strconv.FormatUint(n, 10)+u.name)
+	}
+	if len(parts) == 0 {
+		return "0s"
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// ParseDuration reads "2h 3m", "1d 4h", "1d4h", "90s" or anything
+// time.ParseDuration accepts.
+func ParseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	text := strings.TrimSpace(s)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+	if text == "" {
+		return 0, fmt.Errorf("parse duration %q: empty", s)
+	}
+
+	var total time.Duration
+	for _, field := range strings.Fields(text) {
+		// A field holds one or more number-unit pairs: "4h" or "1d4h".
+		for field != "" {
+			i := strings.IndexFunc(field, func(r rune) bool { return !isASCIIDigit(r) })
+			switch {
+			case i == 0:
+				return 0, fmt.Errorf("parse duration %q: bad number in %q", s, field)
+			case i < 0:
+				return 0, fmt.Errorf("parse duration %q: missing unit in %q", s, field)
+			}
+			j := strings.IndexFunc(field[i:], isASCIIDigit)
+			if j < 0 {
+				j = len(field) - i
+			}
+			number, unit := field[:i], field[i:i+j]
+			field = field[i+j:]
+
+			size := time.Duration(0)
+			for _, u := range durationUnits {
+				if u.name == unit {
+					size = u.size
+					break
+				}
+			}
+			if size == 0 {
+				return 0, fmt.Errorf("parse duration %q: unknown unit %q", s, unit)
+			}
+			n, err := strconv.ParseInt(number, 10, 64)
+			if err != nil || n > math.MaxInt64/int64(size) || total > math.MaxInt64-time.Duration(n)*size {
+				return 0, fmt.Errorf("parse duration %q: out of range", s)
+			}
+			total += time.Duration(n) * size
+		}
+	}
+	if negative {
+		total = -total
+	}
+	return total, nil
+}
+
+func (d Duration) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), int64(d))
+	case 'v', 's':
+		if f.Flag('#') {
+			writePadded(f, FormatDuration(time.Duration(d), 0, true))
+		} else {
+			writePadded(f, FormatDuration(time.Duration(d), 2, false))
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(Duration=%d)", verb, int64(d))
+	}
+}
+
+// ---- Relative times ----
+
+var relativeUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// RelativeTime describes t compared to now: "3 days ago", "in 2 hours", "just now".
+func RelativeTime(t, now time.Time) string {
+	diff := now.Sub(t)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+	if diff < 10*time.Second {
+		return "just now"
+	}
+
+	for _, u := range relativeUnits {
+		if diff < u.size {
+			continue
+		}
+		n := int64(diff / u.size)
+		phrase := fmt.Sprintf("%d %s", n, u.name)
+		if n != 1 {
+			phrase += "s"
+		}
+		if future {
+			return "in " + phrase
+		}
+		return phrase + " ago"
+	}
+	return "just now"
+}
+
+// ParseRelativeTime reads RelativeTime's output back into a time near now.
+func ParseRelativeTime(s string, now time.Time) (time.Time, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	if text == "just now" || text == "now" {
+		return now, nil
+	}
+
+	sign := time.Duration(-1)
+	switch {
+	case strings.HasSuffix(text, " ago"):
+		text = strings.TrimSuffix(text, " ago")
+	case strings.HasPrefix(text, "in "):
+		text = strings.TrimPrefix(text, "in ")
+		sign = 1
+	default:
+		return time.Time{}, fmt.Errorf("parse relative time %q: want \"N units ago\" or \"in N units\"", s)
+	}
+
+	number, unit, _ := strings.Cut(text, " ")
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse relative time %q: bad number", s)
+	}
+	unit = strings.TrimSuffix(unit, "s")
+	for _, u := range relativeUnits {
+		if u.name == unit {
+			return now.Add(sign * time.Duration(n) * u.size), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parse relative time %q: unknown unit %q", s, unit)
+}
+
+// ---- Compact numbers and thousands separators ----
+
+type Count int64
+
+var compactSuffixes = []string{"", "K", "M", "B", "T", "Q"}
+
+// Compact writes n as "1.2K", "3.4M" with prec decimals (trailing zeros dropped).
+func Compact(n int64, prec int) string {
+	sign := ""
+	value := float64(n)
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	exp := 0
+	for value >= 1000 && exp < len(compactSuffixes)-1 {
+		value /= 1000
+		exp++
+	}
+	// 999_950 rounds to "1000K"; move to the next unit instead.
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'f', prec, 64), 64)
+	if rounded >= 1000 && exp < len(compactSuffixes)-1 {
+		value /= 1000
+		exp++
+	}
+	s := strconv.FormatFloat(value, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return sign + s + compactSuffixes[exp]
+}
+
+// ParseCompact reads "1.2K", "3.4m" or "15" back into a number.
+func ParseCompact(s string) (int64, error) {
+	number, suffix := splitNumber(s)
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %q: bad number", s)
+	}
+	i := indexFold(compactSuffixes, suffix)
+	if i < 0 {
+		return 0, fmt.Errorf("parse %q: unknown suffix %q", s, suffix)
+	}
+	return int64(math.Round(value * math.Pow(1000, float64(i)))), nil
+}
+
+// Comma writes n with thousands separators: 1234567 -> "1,234,567".
+func Comma(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	var sb strings.Builder
+	for i, c := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(c)
+	}
+	return sign + sb.String()
+}
+
+// ParseComma reads "1,234,567" back into a number. Groups must have 3 digits.
+func ParseComma(s string) (int64, error) {
+	text := strings.TrimSpace(s)
+	groups := strings.Split(strings.TrimPrefix(text, "-"), ",")
+	for i, g := range groups {
+		if (i > 0 && len(g) != 3) || (i == 0 && (len(g) == 0 || len(g) > 3 && len(groups) > 1)) {
+			return 0, fmt.Errorf("parse %q: misplaced thousands separator", s)
+		}
+	}
+	return strconv.ParseInt(strings.ReplaceAll(text, ",", ""), 10, 64)
+}
+
+func (c Count) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), int64(c))
+	case 'v', 's':
+		if f.Flag('#') {
+			writePadded(f, Comma(int64(c)))
+			return
+		}
+		prec, ok := f.Precision()
+		if !ok {
+			prec = 1
+		}
+		writePadded(f, Compact(int64(c), prec))
+	default:
+		fmt.Fprintf(f, "%%!%c(Count=%d)", verb, int64(c))
+	}
+}
+
+// ---- Ordinals ----
+
+type Ordinal int
+
+// FormatOrdinal writes n with its English suffix: 1st, 2nd, 3rd, 4th, 11th, 21st.
+func FormatOrdinal(n int) string {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	suffix := "th"
+	switch {
+	case abs%100 >= 11 && abs%100 <= 13:
+		// 11th, 12th, 13th
+	case abs%10 == 1:
+		suffix = "st"
+	case abs%10 == 2:
+		suffix = "nd"
+	case abs%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+var ErrBadOrdinal = errors.New("bad ordinal")
+
+// ParseOrdinal reads "21st" back into 21; the suffix must match the number.
+func ParseOrdinal(s string) (int, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	if len(text) < 3 {
+		return 0, fmt.Errorf("parse %q: %w", s, ErrBadOrdinal)
+	}
+	n, err := strconv.Atoi(text[:len(text)-2])
+	if err != nil || FormatOrdinal(n) != text {
+		return 0, fmt.Errorf("parse %q: %w", s, ErrBadOrdinal)
+	}
+	return n, nil
+}
+
+func (o Ordinal) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), int(o))
+	case 'v', 's':
+		writePadded(f, FormatOrdinal(int(o)))
+	default:
+		fmt.Fprintf(f, "%%!%c(Ordinal=%d)", verb, int(o))
+	}
+}
+
+// ---- helpers ----
+
+// writePadded applies the width and '-' flag of a Printf directive to s.
+func writePadded(f fmt.State, s string) {
+	width, ok := f.Width()
+	pad := ""
+	if ok {
+		if n := width - len([]rune(s)); n > 0 {
+			pad = strings.Repeat(" ", n)
+		}
+	}
+	if f.Flag('-') {
+		fmt.Fprint(f, s+pad)
+	} else {
+		fmt.Fprint(f, pad+s)
+	}
+}
+
+// splitNumber splits "1.5MiB" or "1.5 MiB" into "1.5" and "MiB".
+func splitNumber(s string) (number, unit string) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != '-' && r != '+'
+	})
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i:])
+}
+
+func isASCIIDigit(r rune) bool { return '0' <= r && r <= '9' }
+
+func indexFold(list []string, s string) int {
+	for i, item := range list {
+		if strings.EqualFold(item, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+func main() {
+
+	fmt.Println("Byte sizes:")
+	fmt.Println(FormatBytes(1536000, false, 1), "|", FormatBytes(1536000, true, 1)) // 1.5 MB | 1.5 MiB
+	for _, s := range []string{"1.5 MB", "1.5MiB", "10 kb", "2048", "3 XB"} {
+		n, err := ParseBytes(s)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("%q = %d bytes\n", s, n)
+	}
+
+	fmt.Println("Durations:")
+	d := 2*time.Hour + 3*time.Minute + 12*time.Second
+	fmt.Println(FormatDuration(d, 2, false), "|", FormatDuration(d, 0, false)) // 2h 3m | 2h 3m 12s
+	fmt.Println(FormatDuration(1500*time.Millisecond, 0, false))               // 1s 500ms
+	if back, err := ParseDuration("1d 2h 30m"); err == nil {
+		fmt.Println("1d 2h 30m =", back)
+	}
+
+	fmt.Println("Relative times:")
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	fmt.Println(RelativeTime(now.Add(-72*time.Hour), now)) // 3 days ago
+	fmt.Println(RelativeTime(now.Add(2*time.Hour), now))   // in 2 hours
+	fmt.Println(RelativeTime(now.Add(-time.Second), now))  // just now
+	if t, err := ParseRelativeTime("3 days ago", now); err == nil {
+		fmt.Println("3 days ago =", t.Format(time.DateOnly))
+	}
+
+	fmt.Println("Compact numbers:")
+	for _, n := range []int64{999, 1234, 1234567, 3_400_000_000, 999_999} {
+		fmt.Printf("%d -> %s, %s\n", n, Compact(n, 1), Comma(n))
+	}
+	if n, err := ParseCompact("3.4M"); err == nil {
+		fmt.Println("3.4M =", n)
+	}
+	if _, err := ParseComma("12,34"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("Ordinals:")
+	for _, n := range []int{1, 2, 3, 4, 11, 12, 13, 21, 102, 111} {
+		fmt.Print(FormatOrdinal(n), " ")
+	}
+	fmt.Println()
+	if _, err := ParseOrdinal("21th"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("With Printf verbs and flags:")
+	fmt.Printf("[%v] [%#v] [%.2v] [%d]\n", Bytes(1536000), Bytes(1536000), Bytes(1536000), Bytes(1536000))
+	fmt.Printf("[%v] [%#v]\n", Duration(d), Duration(d))
+	fmt.Printf("[%v] [%#v] [%.2v]\n", Count(1234567), Count(1234567), Count(1234567))
+	fmt.Printf("[%10v] [%-10v] [%05d]\n", Ordinal(21), Ordinal(22), Ordinal(23))
+}
@@ -0,0 +1,468 @@
+/*
+	Struct Dumper:
+
+		%v, %+v and %#v print a whole struct on one line. Once structs hold
+		other structs, maps and pointers, the output gets hard to read, and
+		pointers only show up as addresses (0xc000010000).
+
+		Dumper walks a value with reflection and prints it one field per line:
+
+		Person{
+		  Name: "Abhinish",
+		  age: 24,
+		  Address: &Address{
+		    City: "Pune",
+		  },
+		  Password: "****",
+		}
+
+		Option			What it does
+		MaxDepth		stop descending after N levels ("...")
+		MaxLen			show at most N elements / characters, then "... N more"
+		SortKeys		print map keys in sorted order (default on)
+		Unexported		include lowercase fields like age
+		Color			ANSI colors for terminals
+
+		Struct tags:
+			dump:"-"		never print the field
+			dump:"mask"		print "****" instead of the value (passwords, tokens)
+
+		Cycles (a pointer, map or slice that leads back to itself) print as
+		<cycle *T> instead of looping forever.
+
+		Tests compare dumps of tricky values with golden files in testdata/:
+
+			go test main.go main_test.go
+			go test main.go main_test.go -update		rewrite the golden files
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dumper prints values as indented, multi-line Go-like text.
+type Dumper struct {
+	Indent     string
+	MaxDepth   int // 0 means no limit
+	MaxLen     int // 0 means no limit
+	SortKeys   bool
+	Unexported bool
+	Color      bool
+}
+
+// NewDumper returns a dumper with two-space indents, sorted map keys and
+// unexported fields shown.
+func NewDumper() *Dumper {
+	return &Dumper{Indent: "  ", SortKeys: true, Unexported: true}
+}
+
+// Dump returns the dump of v as a string.
+func (d *Dumper) Dump(v any) string {
+	var sb strings.Builder
+	d.Fdump(&sb, v)
+	return sb.String()
+}
+
+// Fdump writes the dump of v to w.
+func (d *Dumper) Fdump(w io.Writer, v any) {
+	p := &printer{Dumper: d, w: w, visiting: map[visit]bool{}}
+	p.value(reflect.ValueOf(v), 0)
+	io.WriteString(w, "\n")
+}
+
+const (
+	colorReset  = "\x1b[0m"
+	colorType   = "\x1b[90m" // gray
+	colorString = "\x1b[32m" // green
+	colorNumber = "\x1b[36m" // cyan
+	colorField  = "\x1b[33m" // yellow
+	colorNil    = "\x1b[31m" // red
+)
+
+type printer struct {
+	*Dumper
+	w        io.Writer
+	visiting map[visit]bool // pointers on the current path, for cycle detection
+	inKey    bool           // printing a map key, which MaxLen never shortens
+}
+
+// visit is a pointer on the current path. The type is part of the key: a
+// struct and its first field share an address but are not a cycle.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// enter marks v as being printed. It returns false if v is already on the
+// current path; otherwise the caller must call leave when done.
+func (p *printer) enter(v reflect.Value) bool {
+	key := visit{v.Pointer(), v.Type()}
+	if p.visiting[key] {
+		p.colored(colorType, "<cycle "+typeName(v.Type())+">")
+		return false
+	}
+	p.visiting[key] = true
+	return true
+}
+
+func (p *printer) leave(v reflect.Value) {
+	delete(p.visiting, visit{v.Pointer(), v.Type()})
+}
+
+// maxLen is the MaxLen that applies to the value being printed.
+func (p *printer) maxLen() int {
+	if p.inKey {
+		return 0
+	}
+	return p.MaxLen
+}
+
+func (p *printer) write(s string) {
+	io.WriteString(p.w, s)
+}
+
+func (p *printer) colored(color, s string) {
+	if p.Color {
+		s = color + s + colorReset
+	}
+	p.write(s)
+}
+
+func (p *printer) newline(depth int) {
+	p.write("\n" + strings.Repeat(p.Indent, depth))
+}
+
+var stringerType = reflect.TypeFor[fmt.Stringer]()
+
+func (p *printer) value(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		p.colored(colorNil, "nil")
+		return
+	}
+
+	// Types like time.Time and time.Duration read better through their
+	// String method. Pointers and interfaces are unwrapped first.
+	if v.Type().Implements(stringerType) && v.CanInterface() && !isNilOrIndirect(v) {
+		p.colored(colorType, v.Type().String())
+		p.write("(")
+		p.colored(colorString, strconv.Quote(v.Interface().(fmt.Stringer).String()))
+		p.write(")")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			p.colored(colorNil, "nil")
+			return
+		}
+		if !p.enter(v) {
+			return
+		}
+		defer p.leave(v)
+		p.write("&")
+		p.value(v.Elem(), depth)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			p.colored(colorNil, "nil")
+			return
+		}
+		p.value(v.Elem(), depth)
+
+	case reflect.Struct:
+		p.structValue(v, depth)
+
+	case reflect.Map:
+		p.mapValue(v, depth)
+
+	case reflect.Slice, reflect.Array:
+		p.listValue(v, depth)
+
+	case reflect.String:
+		s := v.String()
+		more := ""
+		if limit := p.maxLen(); limit > 0 && len([]rune(s)) > limit {
+			r := []rune(s)
+			more = fmt.Sprintf("... %d more", len(r)-limit)
+			s = string(r[:limit])
+		}
+		p.colored(colorString, strconv.Quote(s))
+		if more != "" {
+			p.colored(colorType, more)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.colored(colorNumber, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.colored(colorNumber, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		p.colored(colorNumber, strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		p.colored(colorNumber, strconv.FormatComplex(v.Complex(), 'g', -1, 128))
+	case reflect.Bool:
+		p.colored(colorNumber, strconv.FormatBool(v.Bool()))
+
+	default: // func, chan, unsafe pointer
+		if v.IsNil() {
+			p.colored(colorNil, "nil")
+			return
+		}
+		p.colored(colorType, fmt.Sprintf("%s(%#x)", v.Type(), v.Pointer()))
+	}
+}
+
+func (p *printer) structValue(v reflect.Value, depth int) {
+	t := v.Type()
+	p.colored(colorType, typeName(t))
+	if p.MaxDepth > 0 && depth >= p.MaxDepth {
+		p.write("{...}")
+		return
+	}
+
+	p.write("{")
+	wrote := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("dump")
+		if tag == "-" || (!f.IsExported() && !p.Unexported) {
+			continue
+		}
+		wrote = true
+		p.newline(depth + 1)
+		p.colored(colorField, f.Name)
+		p.write(": ")
+		if tag == "mask" {
+			p.colored(colorString, `"****"`)
+		} else {
+			p.value(v.Field(i), depth+1)
+		}
+		p.write(",")
+	}
+	if wrote {
+		p.newline(depth)
+	}
+	p.write("}")
+}
+
+func (p *printer) mapValue(v reflect.Value, depth int) {
+	if !v.IsNil() {
+		if !p.enter(v) {
+			return
+		}
+		defer p.leave(v)
+	}
+	p.colored(colorType, typeName(v.Type()))
+	if v.IsNil() {
+		p.write("(")
+		p.colored(colorNil, "nil")
+		p.write(")")
+		return
+	}
+	if p.MaxDepth > 0 && depth >= p.MaxDepth {
+		p.write("{...}")
+		return
+	}
+
+	keys := v.MapKeys()
+	if p.SortKeys {
+		slices.SortFunc(keys, compareKeys)
+	}
+
+	p.write("{")
+	for i, k := range keys {
+		if limit := p.maxLen(); limit > 0 && i == limit {
+			p.newline(depth + 1)
+			p.colored(colorType, fmt.Sprintf("... %d more", len(keys)-i))
+			break
+		}
+		p.newline(depth + 1)
+		inKey := p.inKey
+		p.inKey = true
+		p.value(k, depth+1)
+		p.inKey = inKey
+		p.write(": ")
+		p.value(v.MapIndex(k), depth+1)
+		p.write(",")
+	}
+	if len(keys) > 0 {
+		p.newline(depth)
+	}
+	p.write("}")
+}
+
+func (p *printer) listValue(v reflect.Value, depth int) {
+	// A slice can hold itself through an interface element.
+	if v.Kind() == reflect.Slice && v.Len() > 0 {
+		if !p.enter(v) {
+			return
+		}
+		defer p.leave(v)
+	}
+	p.colored(colorType, typeName(v.Type()))
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		p.write("(")
+		p.colored(colorNil, "nil")
+		p.write(")")
+		return
+	}
+	if p.MaxDepth > 0 && depth >= p.MaxDepth && v.Len() > 0 {
+		p.write("{...}")
+		return
+	}
+
+	p.write("{")
+	for i := 0; i < v.Len(); i++ {
+		if limit := p.maxLen(); limit > 0 && i == limit {
+			p.newline(depth + 1)
+			p.colored(colorType, fmt.Sprintf("... %d more", v.Len()-i))
+			break
+		}
+		p.newline(depth + 1)
+		p.value(v.Index(i), depth+1)
+		p.write(",")
+	}
+	if v.Len() > 0 {
+		p.newline(depth)
+	}
+	p.write("}")
+}
+
+// isNilOrIndirect reports whether v is a pointer or interface, which value
+// unwraps before looking for a String method, or a nil map, slice, func or chan.
+func isNilOrIndirect(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return true
+	case reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// typeName leaves out the package of types declared in main, to keep the
+// output short: *Person, not *main.Person. Other packages keep theirs
+// (time.Duration, domain.User).
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		// Compare the package name, not PkgPath: under go test, package
+		// main's path is "command-line-arguments".
+		if name, ok := strings.CutPrefix(t.String(), "main."); ok && t.PkgPath() != "" {
+			return name
+		}
+		return t.String()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(t.Len()) + "]" + typeName(t.Elem())
+	case reflect.Map:
+		return "map[" + typeName(t.Key()) + "]" + typeName(t.Elem())
+	case reflect.Chan:
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + typeName(t.Elem())
+		case reflect.SendDir:
+			return "chan<- " + typeName(t.Elem())
+		}
+		return "chan " + typeName(t.Elem())
+	}
+	return t.String()
+}
+
+// compareKeys orders map keys: numbers numerically, everything else by text.
+func compareKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmpOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmpOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmpOrdered(a.Float(), b.Float())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func cmpOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+type Address struct {
+	City    string
+	Country string
+}
+
+type Person struct {
+	Name     string
+	age      int
+	Address  *Address
+	Tags     []string
+	Scores   map[string]int
+	Password string `dump:"mask"`
+	internal string `dump:"-"`
+	Joined   time.Time
+	Manager  *Person
+	Extra    any
+}
+
+func main() {
+
+	p := Person{
+		Name:     "Abhinish",
+		age:      24,
+		Address:  &Address{City: "Pune", Country: "India"},
+		Tags:     []string{"go", "backend", "learning", "formatting", "reflection"},
+		Scores:   map[string]int{"math": 90, "art": 75, "go": 99},
+		Password: "hunter2",
+		internal: "never shown",
+		Joined:   time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC),
+	}
+	p.Manager = &p // a cycle: %v would print the address, a naive dumper would loop forever
+
+	fmt.Printf("Default format: %v\n", p.Address)
+	fmt.Println("Dumper:")
+
+	d := NewDumper()
+	fmt.Print(d.Dump(&p))
+
+	fmt.Println("MaxDepth 1, MaxLen 2, exported only:")
+	limited := NewDumper()
+	limited.MaxDepth = 1
+	limited.MaxLen = 2
+	limited.Unexported = false
+	fmt.Print(limited.Dump(&p))
+
+	fmt.Println("Tricky values:")
+	loop := []any{1, nil}
+	loop[1] = loop // a slice that contains itself
+	d.Fdump(os.Stdout, map[int]any{
+		3:  loop,
+		1:  [2]bool{true, false},
+		2:  (*Person)(nil),
+		10: 1 + 2i,
+	})
+
+	fmt.Println("With color:")
+	d.Color = true
+	d.Fdump(os.Stdout, Address{City: "Pune", Country: "India"})
+}
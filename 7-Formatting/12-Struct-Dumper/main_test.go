@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+type node struct {
+	Value    int
+	Next     *node
+	Children map[string]*node
+}
+
+type Secret struct {
+	User  string
+	Token string `dump:"mask"`
+	cache []byte `dump:"-"`
+}
+
+// holder points into itself: Self and the holder share an address.
+type holder struct {
+	N    int
+	Self *int
+}
+
+type Embedded struct {
+	Address
+	Count *int
+}
+
+func tricky() map[string]any {
+	ring := &node{Value: 1}
+	ring.Next = &node{Value: 2, Next: ring}
+
+	tree := &node{Value: 0, Children: map[string]*node{
+		"b": {Value: 2},
+		"a": {Value: 1, Children: map[string]*node{"z": nil}},
+	}}
+
+	selfMap := map[string]any{"name": "self"}
+	selfMap["me"] = selfMap
+
+	count := 3
+	countPtr := &count
+
+	h := &holder{N: 5}
+	h.Self = &h.N
+
+	return map[string]any{
+		"ring":        ring,
+		"tree":        tree,
+		"selfMap":     selfMap,
+		"secret":      Secret{User: "admin", Token: "s3cr3t", cache: []byte("hidden")},
+		"embedded":    Embedded{Address{"Pune", "India"}, &count},
+		"ptrToPtr":    &countPtr,
+		"firstField":  h,
+		"nilMap":      map[string]int(nil),
+		"emptySlice":  []int{},
+		"bytes":       []byte("hi"),
+		"intKeys":     map[int]string{10: "ten", 2: "two", -1: "minus one"},
+		"floatKeys":   map[float64]bool{2.5: true, -0.5: false},
+		"duration":    90 * time.Second,
+		"interfaceIn": []any{nil, 1, "two", Address{City: "Delhi"}},
+		"typed":       map[string][]*Address{"home": {{City: "Pune"}, nil}},
+		"array":       [3]uint8{1, 2, 3},
+		"unicode":     "naïve ☃",
+	}
+}
+
+func TestGolden(t *testing.T) {
+	limited := NewDumper()
+	limited.MaxDepth = 2
+	limited.MaxLen = 3
+	limited.Unexported = false
+
+	colored := NewDumper()
+	colored.Color = true
+
+	tests := []struct {
+		name string
+		d    *Dumper
+		v    any
+	}{
+		{"tricky", NewDumper(), tricky()},
+		{"limits", limited, tricky()},
+		{"color", colored, Secret{User: "admin", Token: "x"}},
+		{"nil", NewDumper(), nil},
+		{"nil-pointer", NewDumper(), (*node)(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.d.Dump(tt.v)
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if got != string(want) {
+				t.Errorf("dump differs from %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		t    reflect.Type
+		want string
+	}{
+		{reflect.TypeFor[Person](), "Person"},
+		{reflect.TypeFor[*Person](), "*Person"},
+		{reflect.TypeFor[map[string][]*Address](), "map[string][]*Address"},
+		{reflect.TypeFor[[2]chan<- Address](), "[2]chan<- Address"},
+		{reflect.TypeFor[<-chan *node](), "<-chan *node"},
+		{reflect.TypeFor[time.Duration](), "time.Duration"},
+		{reflect.TypeFor[map[time.Month]Secret](), "map[time.Month]Secret"},
+		{reflect.TypeFor[any](), "interface {}"},
+	}
+	for _, tt := range tests {
+		if got := typeName(tt.t); got != tt.want {
+			t.Errorf("typeName(%s) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
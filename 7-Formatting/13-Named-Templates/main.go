@@ -0,0 +1,274 @@
+/*
+	Named Placeholders:
+
+		fmt.Sprintf("Name: %s, Age: %d", name, age) depends on argument order.
+		If a translation swaps the order ("Age: %d, Name: %s"), the output breaks.
+		Named placeholders say which value goes where:
+
+		"Name: {name}, Age: {age:03d}"		-> Name: Alise, Age: 023
+
+		Syntax						Meaning
+		{name}						value with %v
+		{age:03d}					value with %03d (any printf flags/width/precision/verb)
+		{price:.2f}					value with %.2f
+		{user.Name}					field or map key, dotted path
+		{{ and }}					literal { and }
+
+		Values come from a map[string]any or a struct (field names or `fmt:"name"` tags).
+
+		Compile once, Execute many times: parsing happens in Compile, so
+		Execute only looks up values and formats them.
+		Test, and compare against fmt.Sprintf, with:
+
+			go test main.go main_test.go
+			go test -bench . -benchmem main.go main_test.go
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// segment is either literal text or a placeholder.
+type segment struct {
+	literal string
+	path    []string // placeholder key split on "."; nil for literals
+	format  string   // printf directive, e.g. "%03d"
+}
+
+// Template is a compiled format string.
+type Template struct {
+	source   string
+	segments []segment
+}
+
+// ErrMissingKey is wrapped by Execute when a placeholder has no value.
+var ErrMissingKey = errors.New("missing key")
+
+// SyntaxError points at the offending byte in the template.
+type SyntaxError struct {
+	Template string
+	Offset   int
+	Msg      string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("template %q: %s at offset %d", e.Template, e.Msg, e.Offset)
+}
+
+// allowed printf verbs in a format spec
+const verbs = "vdsqxXobcfFeEgGtTUp"
+
+// Compile parses a template once so it can be executed many times.
+func Compile(src string) (*Template, error) {
+	t := &Template{source: src}
+	var lit strings.Builder
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '{' && i+1 < len(src) && src[i+1] == '{':
+			lit.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(src) && src[i+1] == '}':
+			lit.WriteByte('}')
+			i++
+		case c == '}':
+			return nil, &SyntaxError{src, i, "unmatched '}' (use '}}' for a literal brace)"}
+		case c == '{':
+			end := strings.IndexByte(src[i:], '}')
+			if end < 0 {
+				return nil, &SyntaxError{src, i, "unclosed '{'"}
+			}
+			seg, err := parsePlaceholder(src, i, src[i+1:i+end])
+			if err != nil {
+				return nil, err
+			}
+			if lit.Len() > 0 {
+				t.segments = append(t.segments, segment{literal: lit.String()})
+				lit.Reset()
+			}
+			t.segments = append(t.segments, seg)
+			i += end
+		default:
+			lit.WriteByte(c)
+		}
+	}
+	if lit.Len() > 0 {
+		t.segments = append(t.segments, segment{literal: lit.String()})
+	}
+	return t, nil
+}
+
+// MustCompile is like Compile but panics on error; for templates written in code.
+func MustCompile(src string) *Template {
+	t, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// parsePlaceholder reads "name" or "name:spec" found at offset in src.
+func parsePlaceholder(src string, offset int, body string) (segment, error) {
+	key, spec, hasSpec := strings.Cut(body, ":")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return segment{}, &SyntaxError{src, offset, "empty placeholder name"}
+	}
+	for _, part := range strings.Split(key, ".") {
+		if part == "" {
+			return segment{}, &SyntaxError{src, offset, fmt.Sprintf("bad placeholder name %q", key)}
+		}
+	}
+
+	format := "%v"
+	if hasSpec {
+		if spec == "" || !strings.ContainsRune(verbs, rune(spec[len(spec)-1])) {
+			return segment{}, &SyntaxError{src, offset, fmt.Sprintf("format spec %q must end in a printf verb", spec)}
+		}
+		for _, r := range spec[:len(spec)-1] {
+			if !strings.ContainsRune("+-# 0123456789.", r) {
+				return segment{}, &SyntaxError{src, offset, fmt.Sprintf("format spec %q has invalid flag %q", spec, r)}
+			}
+		}
+		format = "%" + spec
+	}
+	return segment{path: strings.Split(key, "."), format: format}, nil
+}
+
+// Execute fills the template from a map[string]any or a struct.
+func (t *Template) Execute(data any) (string, error) {
+	var sb strings.Builder
+	sb.Grow(len(t.source) + 16)
+	flat, isMap := data.(map[string]any)
+	root := reflect.ValueOf(data)
+
+	for _, seg := range t.segments {
+		if seg.path == nil {
+			sb.WriteString(seg.literal)
+			continue
+		}
+
+		// Fast path: a plain map and a key without dots needs no reflection.
+		if isMap && len(seg.path) == 1 {
+			v, ok := flat[seg.path[0]]
+			if !ok {
+				return "", fmt.Errorf("template %q: %w: %q", t.source, ErrMissingKey, seg.path[0])
+			}
+			fmt.Fprintf(&sb, seg.format, v)
+			continue
+		}
+
+		v, err := lookup(root, seg.path)
+		if err != nil {
+			return "", fmt.Errorf("template %q: %w", t.source, err)
+		}
+		fmt.Fprintf(&sb, seg.format, v)
+	}
+	return sb.String(), nil
+}
+
+// Keys returns the placeholder names in order, e.g. for checking translations.
+func (t *Template) Keys() []string {
+	var keys []string
+	for _, seg := range t.segments {
+		if seg.path != nil {
+			keys = append(keys, strings.Join(seg.path, "."))
+		}
+	}
+	return keys
+}
+
+// lookup follows a dotted path through maps, structs and pointers.
+func lookup(v reflect.Value, path []string) (any, error) {
+	for i, name := range path {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf("%w: %q (nil before %q)", ErrMissingKey, strings.Join(path, "."), name)
+			}
+			v = v.Elem()
+		}
+
+		var next reflect.Value
+		switch v.Kind() {
+		case reflect.Map:
+			if v.Type().Key().Kind() == reflect.String {
+				next = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			}
+		case reflect.Struct:
+			next = structField(v, name)
+		}
+		if !next.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrMissingKey, strings.Join(path[:i+1], "."))
+		}
+		v = next
+	}
+	return v.Interface(), nil
+}
+
+// structField finds an exported field by `fmt:"name"` tag, then by name
+// (case-insensitive, so {name} finds Name).
+func structField(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && f.Tag.Get("fmt") == name {
+			return v.Field(i)
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && strings.EqualFold(f.Name, name) {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+type User struct {
+	Name    string
+	Age     int
+	Balance float64 `fmt:"balance"`
+	Address struct {
+		City string
+	}
+}
+
+func main() {
+	name := "Alise"
+	age := 23
+
+	tmpl := MustCompile("Name: {name}, Age: {age:03d}")
+	out, err := tmpl.Execute(map[string]any{"name": name, "age": age})
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println(out) // Name: Alise, Age: 023
+
+	// A translation can reorder placeholders without touching the code.
+	hindi := MustCompile("उम्र: {age}, नाम: {name}")
+	out, _ = hindi.Execute(map[string]any{"name": name, "age": age})
+	fmt.Println(out)
+
+	user := User{Name: "Bob", Age: 30, Balance: 1234.5}
+	user.Address.City = "Pune"
+	out, err = MustCompile("{name} ({age}) from {address.city} has {balance:.2f} {{credits}}").Execute(&user)
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println(out)
+
+	fmt.Println("Keys:", tmpl.Keys())
+
+	if _, err := tmpl.Execute(map[string]any{"name": name}); errors.Is(err, ErrMissingKey) {
+		fmt.Println("Error:", err)
+	}
+	for _, bad := range []string{"Hi {name", "Hi {}", "Hi {age:03z}", "50% off}"} {
+		if _, err := Compile(bad); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+}
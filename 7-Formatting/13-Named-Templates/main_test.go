@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	user := User{Name: "Bob", Age: 30, Balance: 1234.5}
+	user.Address.City = "Pune"
+	tests := []struct {
+		src  string
+		data any
+		want string
+	}{
+		{"Name: {name}, Age: {age:03d}", map[string]any{"name": "Alise", "age": 23}, "Name: Alise, Age: 023"},
+		{"{age}, {name}", map[string]any{"name": "Alise", "age": 23}, "23, Alise"},
+		{"{ name }", map[string]any{"name": "x"}, "x"},
+		{"{name} from {address.city} has {balance:.2f}", &user, "Bob from Pune has 1234.50"},
+		{"{user.name}", map[string]any{"user": user}, "Bob"},
+		{"{m.k:q}", map[string]any{"m": map[string]int{"k": 1}}, "'\\x01'"},
+		{"no placeholders", nil, "no placeholders"},
+		{"", nil, ""},
+	}
+	for _, tt := range tests {
+		got, err := MustCompile(tt.src).Execute(tt.data)
+		if err != nil || got != tt.want {
+			t.Errorf("%q.Execute = %q, %v; want %q", tt.src, got, err, tt.want)
+		}
+	}
+}
+
+func TestEscaping(t *testing.T) {
+	tmpl := MustCompile("{{literal}} {name} }}{{")
+	got, err := tmpl.Execute(map[string]any{"name": "x"})
+	if err != nil || got != "{literal} x }{" {
+		t.Errorf("Execute = %q, %v; want %q", got, err, "{literal} x }{")
+	}
+	if keys := tmpl.Keys(); !slices.Equal(keys, []string{"name"}) {
+		t.Errorf("Keys() = %q, want only name", keys)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		offset int
+	}{
+		{"Hi {name", 3},
+		{"Hi {}", 3},
+		{"Hi { }", 3},
+		{"{a..b}", 0},
+		{"{.a}", 0},
+		{"50% off}", 7},
+		{"a}b", 1},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.src)
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Offset != tt.offset {
+			t.Errorf("Compile(%q) = %v, want a SyntaxError at offset %d", tt.src, err, tt.offset)
+		}
+	}
+}
+
+func TestSpecValidation(t *testing.T) {
+	for _, spec := range []string{"v", "03d", "-8s", "+.2f", "#x", " d", "10.3e", "q", "T"} {
+		if _, err := Compile("{n:" + spec + "}"); err != nil {
+			t.Errorf("spec %q: %v", spec, err)
+		}
+	}
+	for _, spec := range []string{"", "03z", "d3", "*d", "%d", "ll"} {
+		var se *SyntaxError
+		if _, err := Compile("{n:" + spec + "}"); !errors.As(err, &se) {
+			t.Errorf("spec %q: err = %v, want a SyntaxError", spec, err)
+		}
+	}
+}
+
+func TestMissingKey(t *testing.T) {
+	type withPtr struct{ Next *User }
+	tests := []struct {
+		src  string
+		data any
+	}{
+		{"{age}", map[string]any{"name": "x"}},
+		{"{user.age}", map[string]any{"user": map[string]any{}}},
+		{"{user.name}", map[string]any{"user": 3}},
+		{"{next.name}", withPtr{}},
+		{"{nickname}", User{}},
+		{"{name}", nil},
+		{"{address}", User{}.Address},
+	}
+	for _, tt := range tests {
+		if _, err := MustCompile(tt.src).Execute(tt.data); !errors.Is(err, ErrMissingKey) {
+			t.Errorf("%q.Execute(%#v): err = %v, want ErrMissingKey", tt.src, tt.data, err)
+		}
+	}
+}
+
+const benchFormat = "Name: {name}, Age: {age:03d}"
+
+var benchData = map[string]any{"name": "Alise", "age": 23}
+
+func BenchmarkSprintf(b *testing.B) {
+	for b.Loop() {
+		_ = fmt.Sprintf("Name: %s, Age: %03d", "Alise", 23)
+	}
+}
+
+func BenchmarkExecute(b *testing.B) {
+	tmpl := MustCompile(benchFormat)
+	for b.Loop() {
+		if _, err := tmpl.Execute(benchData); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkExecuteStruct(b *testing.B) {
+	tmpl := MustCompile("{name} ({age}) from {address.city} has {balance:.2f}")
+	user := User{Name: "Bob", Age: 30, Balance: 1234.5}
+	user.Address.City = "Pune"
+	for b.Loop() {
+		if _, err := tmpl.Execute(&user); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompileExecute(b *testing.B) {
+	for b.Loop() {
+		tmpl, err := Compile(benchFormat)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := tmpl.Execute(benchData); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
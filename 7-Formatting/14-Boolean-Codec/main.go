@@ -0,0 +1,304 @@
+/*
+	Boolean Parsing & Formatting:
+
+		%t only prints true/false, and strconv.ParseBool only accepts
+		1, t, T, TRUE, true, True, 0, f, F, FALSE, false, False.
+		Config files and CSV exports also say "yes", "on", "Y" or "ja".
+
+		- A Vocabulary is a set of words for true and for false ("yes"/"no").
+		  The first word of each list is the one used for output.
+		- A Codec accepts one or more vocabularies and writes one.
+
+		Mode		Matching
+		Strict		exact spelling only, no surrounding spaces
+		Lenient		any letter case, surrounding spaces trimmed
+
+		Tri is a tri-state bool for nullable columns: True, False or Unset.
+		Empty cells and words like "null" or "n/a" parse as Unset.
+
+		Run the tests with:
+
+			go test main.go main_test.go
+*/
+
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Vocabulary lists the accepted words for true and false.
+type Vocabulary struct {
+	Name  string
+	True  []string
+	False []string
+}
+
+var (
+	TrueFalse = Vocabulary{"true/false", []string{"true", "t"}, []string{"false", "f"}}
+	YesNo     = Vocabulary{"yes/no", []string{"yes", "y"}, []string{"no", "n"}}
+	OnOff     = Vocabulary{"on/off", []string{"on"}, []string{"off"}}
+	OneZero   = Vocabulary{"1/0", []string{"1"}, []string{"0"}}
+	German    = Vocabulary{"ja/nein", []string{"ja", "j"}, []string{"nein"}}
+	French    = Vocabulary{"oui/non", []string{"oui", "o"}, []string{"non"}}
+	Spanish   = Vocabulary{"sí/no", []string{"sí", "si", "s"}, []string{"no"}}
+	Hindi     = Vocabulary{"हाँ/नहीं", []string{"हाँ", "हां"}, []string{"नहीं"}}
+)
+
+// nullWords parse as Unset in ParseTri.
+var nullWords = []string{"", "null", "nil", "none", "n/a", "na", "-"}
+
+type Mode int
+
+const (
+	Strict Mode = iota
+	Lenient
+)
+
+var ErrInvalidBool = errors.New("invalid boolean")
+
+// Codec parses and formats booleans with configurable vocabularies.
+type Codec struct {
+	Accept []Vocabulary
+	Output Vocabulary
+	Mode   Mode
+}
+
+// NewCodec accepts the given vocabularies and writes the first one.
+func NewCodec(mode Mode, vocabularies ...Vocabulary) *Codec {
+	if len(vocabularies) == 0 {
+		vocabularies = []Vocabulary{TrueFalse}
+	}
+	return &Codec{Accept: vocabularies, Output: vocabularies[0], Mode: mode}
+}
+
+func (c *Codec) match(word, s string) bool {
+	if c.Mode == Lenient {
+		return strings.EqualFold(word, s)
+	}
+	return word == s
+}
+
+func (c *Codec) normalize(s string) string {
+	if c.Mode == Lenient {
+		return strings.TrimSpace(s)
+	}
+	return s
+}
+
+// Parse converts s to a bool using the accepted vocabularies.
+func (c *Codec) Parse(s string) (bool, error) {
+	text := c.normalize(s)
+	for _, v := range c.Accept {
+		for _, w := range v.True {
+			if c.match(w, text) {
+				return true, nil
+			}
+		}
+		for _, w := range v.False {
+			if c.match(w, text) {
+				return false, nil
+			}
+		}
+	}
+	return false, fmt.Errorf("%w %q (accepted: %s)", ErrInvalidBool, s, c.describe())
+}
+
+// Format writes b with the first word of the output vocabulary, or "true"
+// and "false" when it has none (a zero Codec).
+func (c *Codec) Format(b bool) string {
+	if b {
+		return firstOr(c.Output.True, "true")
+	}
+	return firstOr(c.Output.False, "false")
+}
+
+func firstOr(words []string, fallback string) string {
+	if len(words) == 0 {
+		return fallback
+	}
+	return words[0]
+}
+
+func (c *Codec) describe() string {
+	names := make([]string, len(c.Accept))
+	for i, v := range c.Accept {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Tri is a nullable bool. The zero value is Unset.
+type Tri int8
+
+const (
+	Unset Tri = iota
+	False
+	True
+)
+
+// TriOf converts a bool to True or False.
+func TriOf(b bool) Tri {
+	if b {
+		return True
+	}
+	return False
+}
+
+// Bool returns the value and whether it is set.
+func (t Tri) Bool() (value, ok bool) {
+	return t == True, t != Unset
+}
+
+func (t Tri) String() string {
+	switch t {
+	case True:
+		return "true"
+	case False:
+		return "false"
+	}
+	return "unset"
+}
+
+// ParseTri is Parse that also accepts empty and null-like values as Unset.
+// The null words follow the codec's Mode like the others: Strict takes
+// "null" but not " NULL ".
+func (c *Codec) ParseTri(s string) (Tri, error) {
+	text := c.normalize(s)
+	for _, w := range nullWords {
+		if c.match(w, text) {
+			return Unset, nil
+		}
+	}
+	b, err := c.Parse(s)
+	if err != nil {
+		return Unset, err
+	}
+	return TriOf(b), nil
+}
+
+// FormatTri writes t with the output vocabulary; Unset becomes unsetText.
+func (c *Codec) FormatTri(t Tri, unsetText string) string {
+	if value, ok := t.Bool(); ok {
+		return c.Format(value)
+	}
+	return unsetText
+}
+
+// MarshalJSON writes true, false or null.
+func (t Tri) MarshalJSON() ([]byte, error) {
+	if value, ok := t.Bool(); ok {
+		return json.Marshal(value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON reads true, false or null.
+func (t *Tri) UnmarshalJSON(data []byte) error {
+	var b *bool
+	if err := json.Unmarshal(data, &b); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidBool, data)
+	}
+	if b == nil {
+		*t = Unset
+	} else {
+		*t = TriOf(*b)
+	}
+	return nil
+}
+
+// Scan reads a nullable boolean database column.
+func (t *Tri) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = Unset
+	case bool:
+		*t = TriOf(v)
+	case int64:
+		*t = TriOf(v != 0)
+	case string, []byte:
+		parsed, err := NewCodec(Lenient, TrueFalse, OneZero, YesNo).ParseTri(toString(v))
+		if err != nil {
+			return err
+		}
+		*t = parsed
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidBool, src)
+	}
+	return nil
+}
+
+// Value writes a nullable boolean database column.
+func (t Tri) Value() (driver.Value, error) {
+	if value, ok := t.Bool(); ok {
+		return value, nil
+	}
+	return nil, nil
+}
+
+func toString(v any) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v.(string)
+}
+
+func main() {
+
+	fmt.Println("Boolean value: ", true)
+
+	lenient := NewCodec(Lenient, YesNo, OnOff, OneZero, TrueFalse)
+	for _, s := range []string{"yes", " ON ", "Y", "0", "False", "maybe"} {
+		b, err := lenient.Parse(s)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("%q -> %t -> %s\n", s, b, lenient.Format(b))
+	}
+
+	// Read any vocabulary, write a chosen one.
+	lenient.Output = OnOff
+	fmt.Println("Output as on/off:", lenient.Format(true), lenient.Format(false))
+	lenient.Output = YesNo
+
+	strict := NewCodec(Strict, OnOff)
+	if _, err := strict.Parse("On"); err != nil {
+		fmt.Println("Strict:", err)
+	}
+
+	german := NewCodec(Lenient, German, YesNo)
+	b, _ := german.Parse("JA")
+	fmt.Println("German JA ->", german.Format(b))
+
+	hindi := NewCodec(Lenient, Hindi)
+	fmt.Println("Hindi true ->", hindi.Format(true))
+
+	fmt.Println("Nullable CSV column:")
+	for _, cell := range []string{"Y", "n", "", "N/A"} {
+		t, err := lenient.ParseTri(cell)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		out, _ := json.Marshal(t)
+		fmt.Printf("%-6q -> %-5s json=%s csv=%q\n", cell, t, out, lenient.FormatTri(t, ""))
+	}
+
+	var row struct {
+		Active Tri `json:"active"`
+		Admin  Tri `json:"admin"`
+	}
+	if err := json.Unmarshal([]byte(`{"active": true, "admin": null}`), &row); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println("From JSON:", row.Active, row.Admin)
+
+	var scanned Tri
+	_ = scanned.Scan([]byte("yes"))
+	fmt.Println("Scanned from DB:", scanned)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	lenient := NewCodec(Lenient, YesNo, OnOff, OneZero, TrueFalse, Hindi)
+	strict := NewCodec(Strict, YesNo, OnOff)
+	tests := []struct {
+		c    *Codec
+		in   string
+		want bool
+		ok   bool
+	}{
+		{lenient, "yes", true, true},
+		{lenient, " ON ", true, true},
+		{lenient, "Y", true, true},
+		{lenient, "0", false, true},
+		{lenient, "False", false, true},
+		{lenient, "हां", true, true},
+		{lenient, "maybe", false, false},
+		{lenient, "", false, false},
+		{strict, "on", true, true},
+		{strict, "no", false, true},
+		{strict, "On", false, false},
+		{strict, " on", false, false},
+		{strict, "true", false, false}, // not an accepted vocabulary
+	}
+	for _, tt := range tests {
+		got, err := tt.c.Parse(tt.in)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("Parse(%q) = %t, %v; want %t", tt.in, got, err, tt.want)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidBool) {
+			t.Errorf("Parse(%q) = %t, %v; want ErrInvalidBool", tt.in, got, err)
+		}
+	}
+}
+
+func TestParseTri(t *testing.T) {
+	lenient := NewCodec(Lenient, YesNo)
+	strict := NewCodec(Strict, YesNo)
+	tests := []struct {
+		c    *Codec
+		in   string
+		want Tri
+		ok   bool
+	}{
+		{lenient, "Y", True, true},
+		{lenient, "n", False, true},
+		{lenient, "", Unset, true},
+		{lenient, "  ", Unset, true},
+		{lenient, "N/A", Unset, true},
+		{lenient, " NULL ", Unset, true},
+		{strict, "y", True, true},
+		{strict, "", Unset, true},
+		{strict, "null", Unset, true},
+		{strict, "NULL", Unset, false},
+		{strict, " null", Unset, false},
+		{strict, " ", Unset, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.c.ParseTri(tt.in)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("mode %d: ParseTri(%q) = %s, %v; want %s", tt.c.Mode, tt.in, got, err, tt.want)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidBool) {
+			t.Errorf("mode %d: ParseTri(%q) = %s, %v; want ErrInvalidBool", tt.c.Mode, tt.in, got, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	c := NewCodec(Lenient, German, YesNo)
+	if got := c.Format(true) + "/" + c.Format(false); got != "ja/nein" {
+		t.Errorf("German Format = %s, want ja/nein", got)
+	}
+	c.Output = OnOff
+	if got := c.FormatTri(True, "") + "/" + c.FormatTri(False, "") + "/" + c.FormatTri(Unset, "-"); got != "on/off/-" {
+		t.Errorf("on/off FormatTri = %s, want on/off/-", got)
+	}
+
+	// No output words: fall back instead of panicking.
+	for _, c := range []*Codec{{}, {Output: Vocabulary{Name: "empty"}}} {
+		if got := c.Format(true) + "/" + c.Format(false); got != "true/false" {
+			t.Errorf("empty vocabulary Format = %s, want true/false", got)
+		}
+	}
+}
+
+func TestTriJSON(t *testing.T) {
+	for _, tri := range []Tri{True, False, Unset} {
+		data, err := json.Marshal(tri)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var back Tri
+		if err := json.Unmarshal(data, &back); err != nil || back != tri {
+			t.Errorf("%s -> %s -> %s, %v", tri, data, back, err)
+		}
+	}
+	var bad Tri
+	if err := json.Unmarshal([]byte(`"yes"`), &bad); !errors.Is(err, ErrInvalidBool) {
+		t.Errorf(`Unmarshal("yes"): err = %v, want ErrInvalidBool`, err)
+	}
+}
+
+func TestTriScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want Tri
+	}{
+		{nil, Unset},
+		{true, True},
+		{int64(0), False},
+		{"yes", True},
+		{[]byte("0"), False},
+		{"", Unset},
+	}
+	for _, tt := range tests {
+		var got Tri
+		if err := got.Scan(tt.src); err != nil || got != tt.want {
+			t.Errorf("Scan(%#v) = %s, %v; want %s", tt.src, got, err, tt.want)
+		}
+	}
+	var got Tri
+	if err := got.Scan(1.5); !errors.Is(err, ErrInvalidBool) {
+		t.Errorf("Scan(1.5): err = %v, want ErrInvalidBool", err)
+	}
+	if v, _ := Unset.Value(); v != nil {
+		t.Errorf("Unset.Value() = %v, want nil", v)
+	}
+}
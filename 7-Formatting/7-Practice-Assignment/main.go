@@ -20,15 +20,15 @@ func main() {
 
 	fmt.Println("2. Format a currency value (e.g., Price: $1234.50).")
 
-	strFormatting := fmt.Sprintf("Price:%s%f", "$", 1234.50)
+	strFormatting := fmt.Sprintf("Price: %s%.2f", "$", 1234.50) // %.2f keeps two decimals: $1234.50
 	fmt.Println(strFormatting)
 
 	fmt.Println("3. Convert an integer to binary, octal, and hexadecimal formats.")
 
 	num := 456
 
-	fmt.Printf("Binary: %b", num)
-	fmt.Printf("Octal: %o", num)
-	fmt.Printf("Hexadecimal: %x", num)
+	fmt.Printf("Binary: %b\n", num)
+	fmt.Printf("Octal: %o\n", num)
+	fmt.Printf("Hexadecimal: %x\n", num)
 
 }
@@ -0,0 +1,547 @@
+/*
+	Table Renderer:
+
+		"%-10s" only works when every value is shorter than 10 characters.
+		"percentages" is 11, so the practice-assignment table goes out of line.
+		This table measures every cell first and sizes each column to fit.
+
+		- Width is measured in terminal columns, not bytes or runes:
+		  "Go" = 2, "日本" = 4 (wide CJK), "🚀" = 2 (emoji), "é" (e + accent) = 1.
+		- Alignment per column: Left, Right, Center, Decimal (lines up the ".").
+		- Borders: none, ASCII (+-|) or box drawing (┌─┐).
+		- MaxWidth truncates long cells with "…".
+		- Stripe shades every other row (ANSI in text, a CSS class in HTML).
+
+		Output				Method
+		Plain text			t.Text()
+		Markdown			t.Markdown()
+		CSV					t.CSV()
+		HTML				t.HTML()
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+type Align int
+
+const (
+	Left Align = iota
+	Right
+	Center
+	Decimal
+)
+
+type Border int
+
+const (
+	NoBorder Border = iota
+	ASCIIBorder
+	BoxBorder
+)
+
+// Column describes how one column is rendered.
+type Column struct {
+	Header   string
+	Align    Align
+	MaxWidth int // 0 means no limit
+}
+
+// Table holds rows of cells plus rendering options.
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+	Border  Border
+	Stripe  bool
+}
+
+// NewTable builds a table from header names and rows of cells.
+func NewTable(header []string, rows [][]string) *Table {
+	t := &Table{Rows: rows}
+	for _, h := range header {
+		t.Columns = append(t.Columns, Column{Header: h})
+	}
+	return t
+}
+
+// FromStructs builds a table from a slice of structs. Exported fields become
+// columns; the `table:"Name"` tag renames a column and `table:"-"` skips it.
+// A nil element of a []*T becomes an empty row.
+func FromStructs(slice any) (*Table, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("FromStructs: want a slice of structs, got %T", slice)
+	}
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("FromStructs: want a slice of structs, got %T", slice)
+	}
+
+	var fields []int
+	t := &Table{}
+	for i := 0; i < elem.NumField(); i++ {
+		f := elem.Field(i)
+		name := f.Tag.Get("table")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		col := Column{Header: name}
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			col.Align = Right
+		case reflect.Float32, reflect.Float64:
+			col.Align = Decimal
+		}
+		t.Columns = append(t.Columns, col)
+		fields = append(fields, i)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		for item.Kind() == reflect.Pointer && !item.IsNil() {
+			item = item.Elem()
+		}
+		row := make([]string, len(fields))
+		if item.Kind() == reflect.Pointer {
+			t.Rows = append(t.Rows, row) // nil element: an empty row keeps the count
+			continue
+		}
+		for j, fi := range fields {
+			row[j] = fmt.Sprint(item.Field(fi).Interface())
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+// SetAlign sets the alignment of a column by header name.
+func (t *Table) SetAlign(header string, a Align) *Table {
+	for i := range t.Columns {
+		if t.Columns[i].Header == header {
+			t.Columns[i].Align = a
+		}
+	}
+	return t
+}
+
+// SetMaxWidth limits a column by header name; longer cells end in "…".
+func (t *Table) SetMaxWidth(header string, width int) *Table {
+	for i := range t.Columns {
+		if t.Columns[i].Header == header {
+			t.Columns[i].MaxWidth = width
+		}
+	}
+	return t
+}
+
+// cell returns row[i], or "" for short rows.
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+// layout truncates cells, lines up decimals, and returns the final cell text
+// and the width of every column. If escape is not nil it is applied to every
+// cell before the widths are measured.
+func (t *Table) layout(escape func(string) string) (header []string, rows [][]string, widths []int) {
+	if escape == nil {
+		escape = func(s string) string { return s }
+	}
+	n := len(t.Columns)
+	header = make([]string, n)
+	rows = make([][]string, len(t.Rows))
+	widths = make([]int, n)
+
+	for c, col := range t.Columns {
+		header[c] = escape(truncate(col.Header, col.MaxWidth))
+
+		// Decimal: pad the integer and fraction parts to the widest of each.
+		var intWidth, fracWidth int
+		if col.Align == Decimal {
+			for _, row := range t.Rows {
+				whole, frac, _ := strings.Cut(cell(row, c), ".")
+				intWidth = max(intWidth, DisplayWidth(whole))
+				fracWidth = max(fracWidth, DisplayWidth(frac))
+			}
+		}
+
+		for r, row := range t.Rows {
+			if rows[r] == nil {
+				rows[r] = make([]string, n)
+			}
+			text := cell(row, c)
+			if col.Align == Decimal {
+				whole, frac, hasDot := strings.Cut(text, ".")
+				text = strings.Repeat(" ", intWidth-DisplayWidth(whole)) + whole
+				if hasDot {
+					text += "." + frac + strings.Repeat(" ", fracWidth-DisplayWidth(frac))
+				} else if fracWidth > 0 {
+					text += strings.Repeat(" ", fracWidth+1)
+				}
+			}
+			rows[r][c] = escape(truncate(text, col.MaxWidth))
+		}
+
+		widths[c] = DisplayWidth(header[c])
+		for _, row := range rows {
+			widths[c] = max(widths[c], DisplayWidth(row[c]))
+		}
+	}
+	return header, rows, widths
+}
+
+// pad fills s with spaces to width columns using the given alignment.
+func pad(s string, width int, a Align) string {
+	gap := width - DisplayWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	switch a {
+	case Right, Decimal:
+		return strings.Repeat(" ", gap) + s
+	case Center:
+		left := gap / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	}
+	return s + strings.Repeat(" ", gap)
+}
+
+// borderChars lists the pieces of a frame: corners and joins of the top,
+// middle (header separator) and bottom lines, plus the horizontal and vertical bars.
+type borderChars struct {
+	top, mid, bottom [3]string // left, join, right
+	horizontal       string
+	vertical         string
+}
+
+var borders = map[Border]borderChars{
+	ASCIIBorder: {
+		top: [3]string{"+", "+", "+"}, mid: [3]string{"+", "+", "+"}, bottom: [3]string{"+", "+", "+"},
+		horizontal: "-", vertical: "|",
+	},
+	BoxBorder: {
+		top: [3]string{"┌", "┬", "┐"}, mid: [3]string{"├", "┼", "┤"}, bottom: [3]string{"└", "┴", "┘"},
+		horizontal: "─", vertical: "│",
+	},
+}
+
+const (
+	stripeOn  = "\x1b[48;5;236m"
+	stripeOff = "\x1b[0m"
+)
+
+// Text renders the table for a terminal.
+func (t *Table) Text() string {
+	header, rows, widths := t.layout(nil)
+	var sb strings.Builder
+
+	b, framed := borders[t.Border]
+
+	line := func(parts [3]string) {
+		sb.WriteString(parts[0])
+		for i, w := range widths {
+			if i > 0 {
+				sb.WriteString(parts[1])
+			}
+			sb.WriteString(strings.Repeat(b.horizontal, w+2))
+		}
+		sb.WriteString(parts[2] + "\n")
+	}
+
+	formatRow := func(cells []string, aligns func(i int) Align) string {
+		var row strings.Builder
+		if framed {
+			row.WriteString(b.vertical)
+		}
+		for i, w := range widths {
+			if i > 0 && framed {
+				row.WriteString(b.vertical)
+			} else if i > 0 {
+				row.WriteString("  ")
+			}
+			if framed {
+				row.WriteString(" " + pad(cells[i], w, aligns(i)) + " ")
+			} else {
+				row.WriteString(pad(cells[i], w, aligns(i)))
+			}
+		}
+		if framed {
+			row.WriteString(b.vertical)
+		}
+		return strings.TrimRight(row.String(), " ")
+	}
+
+	if framed {
+		line(b.top)
+	}
+	sb.WriteString(formatRow(header, func(i int) Align {
+		if t.Columns[i].Align == Decimal {
+			return Right
+		}
+		return t.Columns[i].Align
+	}) + "\n")
+
+	if framed {
+		line(b.mid)
+	} else if len(widths) > 0 {
+		total := 0
+		for _, w := range widths {
+			total += w
+		}
+		sb.WriteString(strings.Repeat("-", total+2*(len(widths)-1)) + "\n")
+	}
+
+	for r, cells := range rows {
+		text := formatRow(cells, func(i int) Align { return t.Columns[i].Align })
+		if t.Stripe && r%2 == 1 {
+			text = stripeOn + text + stripeOff
+		}
+		sb.WriteString(text + "\n")
+	}
+
+	if framed {
+		line(b.bottom)
+	}
+	return sb.String()
+}
+
+// Markdown renders a GitHub-flavoured Markdown table.
+func (t *Table) Markdown() string {
+	header, rows, widths := t.layout(func(s string) string { return strings.ReplaceAll(s, "|", `\|`) })
+	var sb strings.Builder
+
+	writeRow := func(cells []string) {
+		sb.WriteString("|")
+		for i, w := range widths {
+			sb.WriteString(" " + pad(cells[i], w, t.Columns[i].Align) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(header)
+	sb.WriteString("|")
+	for i, w := range widths {
+		dashes := strings.Repeat("-", max(w, 3))
+		switch t.Columns[i].Align {
+		case Right, Decimal:
+			dashes = dashes[1:] + ":"
+		case Center:
+			dashes = ":" + dashes[2:] + ":"
+		}
+		sb.WriteString(" " + dashes + " |")
+	}
+	sb.WriteString("\n")
+	for _, cells := range rows {
+		writeRow(cells)
+	}
+	return sb.String()
+}
+
+// CSV renders the raw cells; no padding or truncation.
+func (t *Table) CSV() (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	header := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		header[i] = c.Header
+	}
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+	for _, row := range t.Rows {
+		cells := make([]string, len(t.Columns))
+		for i := range cells {
+			cells[i] = cell(row, i)
+		}
+		if err := w.Write(cells); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+// HTML renders a <table>; striped rows get class="stripe".
+func (t *Table) HTML() string {
+	var sb strings.Builder
+	style := func(a Align) string {
+		switch a {
+		case Right, Decimal:
+			return ` style="text-align:right"`
+		case Center:
+			return ` style="text-align:center"`
+		}
+		return ""
+	}
+
+	sb.WriteString("<table>\n  <thead>\n    <tr>")
+	for _, c := range t.Columns {
+		fmt.Fprintf(&sb, "<th%s>%s</th>", style(c.Align), html.EscapeString(truncate(c.Header, c.MaxWidth)))
+	}
+	sb.WriteString("</tr>\n  </thead>\n  <tbody>\n")
+	for r, row := range t.Rows {
+		if t.Stripe && r%2 == 1 {
+			sb.WriteString(`    <tr class="stripe">`)
+		} else {
+			sb.WriteString("    <tr>")
+		}
+		for i, c := range t.Columns {
+			fmt.Fprintf(&sb, "<td%s>%s</td>", style(c.Align), html.EscapeString(truncate(cell(row, i), c.MaxWidth)))
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("  </tbody>\n</table>\n")
+	return sb.String()
+}
+
+// truncate shortens s to at most width columns, ending in "…".
+func truncate(s string, width int) string {
+	if width <= 0 || DisplayWidth(s) <= width {
+		return s
+	}
+	var sb strings.Builder
+	used := 0
+	for _, r := range s {
+		w := RuneWidth(r)
+		if used+w > width-1 {
+			break
+		}
+		sb.WriteRune(r)
+		used += w
+	}
+	return sb.String() + "…"
+}
+
+// DisplayWidth returns how many terminal columns s takes up.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+	return width
+}
+
+// wideRanges are the code points drawn two columns wide: CJK, Hangul,
+// full-width forms and emoji.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x231A, 0x231B},   // ⌚⌛
+	{0x23E9, 0x23F3},   // ⏩..⏳
+	{0x25FD, 0x25FE},   // ◽◾
+	{0x2614, 0x2615},   // ☔☕
+	{0x2648, 0x2653},   // zodiac signs
+	{0x26A1, 0x26A1},   // ⚡
+	{0x26BD, 0x26BE},   // ⚽⚾
+	{0x2705, 0x2705},   // ✅
+	{0x270A, 0x270B},   // ✊✋
+	{0x2728, 0x2728},   // ✨
+	{0x274C, 0x274C},   // ❌
+	{0x2753, 0x2757},   // ❓..❗
+	{0x2B50, 0x2B50},   // ⭐
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, CJK symbols
+	{0x3400, 0x4DBF},   // CJK Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Full-width forms
+	{0xFFE0, 0xFFE6},   // Full-width signs
+	{0x1F300, 0x1F64F}, // Symbols, pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map symbols
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and pictographs extended-A
+	{0x20000, 0x3FFFD}, // CJK Extensions B and beyond
+}
+
+// RuneWidth returns 0 for combining marks and invisible format characters
+// (like the zero-width joiner inside emoji sequences), 2 for wide characters
+// and 1 otherwise.
+func RuneWidth(r rune) int {
+	if r == 0 || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Cf, r) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0x1F3FB && r <= 0x1F3FF) { // variation selectors, skin tones
+		return 0
+	}
+	for _, rg := range wideRanges {
+		if r >= rg[0] && r <= rg[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+type Student struct {
+	Name       string
+	Grade      int
+	Percentage float64 `table:"Percentage"`
+	notes      string
+}
+
+func main() {
+
+	// The practice-assignment table, sized automatically.
+	grades := NewTable(
+		[]string{"Name", "Grades", "percentages"},
+		[][]string{
+			{"Alice", "25", "20"},
+			{"Bob", "30", "45"},
+			{"Charlie", "28", "70"},
+		},
+	)
+	grades.SetAlign("Grades", Right).SetAlign("percentages", Right)
+	fmt.Println(grades.Text())
+
+	// Emoji and CJK keep their columns lined up.
+	people := NewTable(
+		[]string{"Name", "Age", "Country", "Note"},
+		[][]string{
+			{"Alice", "25", "USA 🇺🇸", "likes 🚀 launches"},
+			{"田中", "30", "日本", "東京に住んでいます"},
+			{"José", "28", "España", "café ☕ every morning before work"},
+		},
+	)
+	people.Border = BoxBorder
+	people.Stripe = true
+	people.SetAlign("Age", Center).SetMaxWidth("Note", 16)
+	fmt.Println(people.Text())
+
+	// From structs: numbers align right, floats on the decimal point.
+	students, err := FromStructs([]Student{
+		{"Alice", 25, 20.5, ""},
+		{"Bob", 30, 45.25, ""},
+		{"Charlie", 28, 7, ""},
+	})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	students.Border = ASCIIBorder
+	fmt.Println(students.Text())
+	fmt.Println(students.Markdown())
+
+	csvText, err := students.CSV()
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println(csvText)
+
+	students.Stripe = true
+	fmt.Println(students.HTML())
+}
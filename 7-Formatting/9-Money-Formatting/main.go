@@ -0,0 +1,463 @@
+/*
+	Money Formatting:
+
+		- float64 can't hold most cent values exactly (0.1 + 0.2 != 0.3),
+		  so money is stored as an integer count of minor units: $1234.50 -> 123450 cents.
+		- Each ISO 4217 currency has its own number of minor digits:
+		  USD 2 (cents), JPY 0, KWD 3 (fils).
+		- Rounding only happens when we multiply or convert, and the mode is explicit:
+
+		Mode		2.345 -> 2 digits	2.355 -> 2 digits	-2.345 -> 2 digits
+		HalfUp		2.35				2.36				-2.35
+		HalfEven	2.34				2.36				-2.34		(banker's rounding)
+		Down		2.34				2.35				-2.34		(truncate)
+
+		- Allocate splits an amount by ratios without losing a cent:
+		  $100 split 3 ways -> $33.34, $33.33, $33.33.
+		- A result too big for int64 minor units is ErrOverflow, never a wrapped value.
+
+		Locale		Output
+		en-US		$1,234.50
+		de-DE		1.234,50 €
+		en-IN		₹1,23,456.00
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency.
+type Currency struct {
+	Code   string
+	Digits int // number of minor-unit digits
+	Symbol string
+}
+
+var currencies = map[string]Currency{
+	"USD": {"USD", 2, "$"},
+	"EUR": {"EUR", 2, "€"},
+	"GBP": {"GBP", 2, "£"},
+	"INR": {"INR", 2, "₹"},
+	"JPY": {"JPY", 0, "¥"},
+	"CHF": {"CHF", 2, "CHF"},
+	"KWD": {"KWD", 3, "KD"},
+	"BHD": {"BHD", 3, "BD"},
+}
+
+// LookupCurrency finds a currency by its ISO 4217 code.
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("unknown currency code %q", code)
+	}
+	return c, nil
+}
+
+type RoundingMode int
+
+const (
+	HalfUp   RoundingMode = iota // ties away from zero
+	HalfEven                     // ties to the even digit (banker's rounding)
+	Down                         // drop the extra digits (towards zero)
+)
+
+func (r RoundingMode) String() string {
+	switch r {
+	case HalfUp:
+		return "HalfUp"
+	case HalfEven:
+		return "HalfEven"
+	case Down:
+		return "Down"
+	}
+	return fmt.Sprintf("RoundingMode(%d)", int(r))
+}
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrOverflow         = errors.New("amount out of range")
+)
+
+// Money is an amount in the minor units of a currency.
+type Money struct {
+	units    int64
+	currency Currency
+}
+
+// FromMinor builds Money from minor units: FromMinor(123450, "USD") is $1234.50.
+func FromMinor(units int64, code string) (Money, error) {
+	c, err := LookupCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, currency: c}, nil
+}
+
+// Parse reads a plain decimal string like "1234.50" or "-0.05". More decimal
+// places than the currency allows is an error rather than a silent rounding.
+func Parse(amount, code string) (Money, error) {
+	c, err := LookupCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+
+	s := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return Money{}, fmt.Errorf("parse %q: no digits", amount)
+	}
+	if len(frac) > c.Digits {
+		return Money{}, fmt.Errorf("parse %q: %s allows %d decimal places", amount, c.Code, c.Digits)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	digits := whole + frac + strings.Repeat("0", c.Digits-len(frac))
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return Money{}, fmt.Errorf("parse %q: %w", amount, ErrOverflow)
+	}
+	if err != nil || strings.ContainsAny(digits, "+-") {
+		return Money{}, fmt.Errorf("parse %q: not a valid amount", amount)
+	}
+	if negative {
+		units = -units
+	}
+	return Money{units: units, currency: c}, nil
+}
+
+func (m Money) Units() int64              { return m.units }
+func (m Money) Currency() Currency        { return m.currency }
+func (m Money) IsZero() bool              { return m.units == 0 }
+func (m Money) IsNegative() bool          { return m.units < 0 }
+func (m Money) sameCurrency(o Money) bool { return m.currency.Code == o.currency.Code }
+
+// Negate returns -m, or ErrOverflow for the most negative amount, whose
+// opposite doesn't fit in int64 minor units.
+func (m Money) Negate() (Money, error) {
+	if m.units == math.MinInt64 {
+		return Money{}, fmt.Errorf("-(%s): %w", m, ErrOverflow)
+	}
+	return Money{-m.units, m.currency}, nil
+}
+
+// Add returns m + o, or ErrOverflow when the sum doesn't fit in int64 minor units.
+func (m Money) Add(o Money) (Money, error) {
+	if !m.sameCurrency(o) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.currency.Code, o.currency.Code)
+	}
+	units, err := toUnits(new(big.Int).Add(big.NewInt(m.units), big.NewInt(o.units)))
+	if err != nil {
+		return Money{}, fmt.Errorf("%s + %s: %w", m, o, err)
+	}
+	return Money{units, m.currency}, nil
+}
+
+// Sub returns m - o, or ErrOverflow when the difference doesn't fit.
+func (m Money) Sub(o Money) (Money, error) {
+	if !m.sameCurrency(o) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrCurrencyMismatch, m.currency.Code, o.currency.Code)
+	}
+	units, err := toUnits(new(big.Int).Sub(big.NewInt(m.units), big.NewInt(o.units)))
+	if err != nil {
+		return Money{}, fmt.Errorf("%s - %s: %w", m, o, err)
+	}
+	return Money{units, m.currency}, nil
+}
+
+// toUnits narrows an exact result back to int64 minor units.
+func toUnits(n *big.Int) (int64, error) {
+	if !n.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return n.Int64(), nil
+}
+
+// Mul multiplies by an exact decimal factor such as "1.0825" (8.25% tax),
+// rounding the result to minor units with mode.
+func (m Money) Mul(factor string, mode RoundingMode) (Money, error) {
+	r, ok := new(big.Rat).SetString(factor)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid factor %q", factor)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(m.units))
+	units, err := roundRat(r, mode)
+	if err != nil {
+		return Money{}, fmt.Errorf("%s * %s: %w", m, factor, err)
+	}
+	return Money{units, m.currency}, nil
+}
+
+// Convert changes currency at an exact rate ("83.12" INR per USD), adjusting
+// for different minor digits and rounding with mode.
+func (m Money) Convert(rate, code string, mode RoundingMode) (Money, error) {
+	to, err := LookupCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid rate %q", rate)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(m.units))
+	scale := new(big.Rat).SetFrac(pow10(to.Digits), pow10(m.currency.Digits))
+	r.Mul(r, scale)
+	units, err := roundRat(r, mode)
+	if err != nil {
+		return Money{}, fmt.Errorf("convert %s to %s: %w", m, to.Code, err)
+	}
+	return Money{units, to}, nil
+}
+
+// Allocate splits m by ratios. Leftover minor units (from rounding down) go
+// one at a time to the first parts, so the parts always add up to m.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("allocate: negative ratio %d", r)
+		}
+		total.Add(total, big.NewInt(int64(r)))
+	}
+	if total.Sign() == 0 {
+		return nil, errors.New("allocate: ratios add up to zero")
+	}
+
+	// units*ratio can exceed int64 even though each share fits, so the
+	// shares are worked out exactly. Each one is at most |m|, and truncating
+	// leaves fewer than len(ratios) units over, so the loop below ends.
+	parts := make([]Money, len(ratios))
+	var allocated int64
+	units := big.NewInt(m.units)
+	for i, r := range ratios {
+		share := new(big.Int).Mul(units, big.NewInt(int64(r)))
+		share.Quo(share, total)
+		parts[i] = Money{share.Int64(), m.currency}
+		allocated += share.Int64()
+	}
+
+	step := int64(1)
+	if m.units < 0 {
+		step = -1
+	}
+	for i := 0; allocated != m.units; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].units += step
+		allocated += step
+	}
+	return parts, nil
+}
+
+// Split divides m into n equal parts (Allocate with equal ratios).
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("split: want at least one part, got %d", n)
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// roundRat rounds r to an integer with mode. It returns ErrOverflow when
+// the result doesn't fit in int64.
+func roundRat(r *big.Rat, mode RoundingMode) (int64, error) {
+	num, den := r.Num(), r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int)) // truncated towards zero
+
+	if rem.Sign() != 0 && mode != Down {
+		// Compare 2*|rem| with den to see if we're below, at or above the half.
+		twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+		half := twice.Cmp(den)
+		away := half > 0 ||
+			(half == 0 && mode == HalfUp) ||
+			(half == 0 && mode == HalfEven && q.Bit(0) == 1)
+		if away {
+			q.Add(q, big.NewInt(int64(num.Sign())))
+		}
+	}
+	return toUnits(q)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Locale describes how a country writes amounts.
+type Locale struct {
+	GroupSep     string
+	DecimalSep   string
+	Grouping     []int // group sizes from the right; the last one repeats
+	SymbolAfter  bool
+	SymbolSpaced bool
+}
+
+var locales = map[string]Locale{
+	"en-US": {GroupSep: ",", DecimalSep: ".", Grouping: []int{3}},
+	"en-GB": {GroupSep: ",", DecimalSep: ".", Grouping: []int{3}},
+	"de-DE": {GroupSep: ".", DecimalSep: ",", Grouping: []int{3}, SymbolAfter: true, SymbolSpaced: true},
+	"fr-FR": {GroupSep: " ", DecimalSep: ",", Grouping: []int{3}, SymbolAfter: true, SymbolSpaced: true},
+	"de-CH": {GroupSep: "’", DecimalSep: ".", Grouping: []int{3}, SymbolSpaced: true},
+	"en-IN": {GroupSep: ",", DecimalSep: ".", Grouping: []int{3, 2}},
+	"ja-JP": {GroupSep: ",", DecimalSep: ".", Grouping: []int{3}},
+}
+
+// Format writes m using the rules of a locale such as "en-US" or "de-DE".
+func (m Money) Format(localeName string) (string, error) {
+	loc, ok := locales[localeName]
+	if !ok {
+		return "", fmt.Errorf("unknown locale %q", localeName)
+	}
+
+	sign, number := m.number(loc)
+	space := ""
+	if loc.SymbolSpaced {
+		space = "\u00a0" // non-breaking, so the symbol never wraps away from the number
+	}
+	if loc.SymbolAfter {
+		return sign + number + space + m.currency.Symbol, nil
+	}
+	return sign + m.currency.Symbol + space + number, nil
+}
+
+// String formats in en-US style with the ISO code, e.g. "1,234.50 USD".
+func (m Money) String() string {
+	sign, number := m.number(locales["en-US"])
+	return sign + number + " " + m.currency.Code
+}
+
+// number returns the sign and the grouped digits of m, without a symbol.
+func (m Money) number(loc Locale) (sign, number string) {
+	// As uint64, so the magnitude of math.MinInt64 doesn't wrap.
+	units := uint64(m.units)
+	if m.units < 0 {
+		sign = "-"
+		units = -units
+	}
+
+	digits := strconv.FormatUint(units, 10)
+	d := m.currency.Digits
+	if len(digits) <= d {
+		digits = strings.Repeat("0", d-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-d], digits[len(digits)-d:]
+
+	number = group(whole, loc.GroupSep, loc.Grouping)
+	if d > 0 {
+		number += loc.DecimalSep + frac
+	}
+	return sign, number
+}
+
+// group inserts sep between digit groups. Grouping {3, 2} gives the Indian
+// style 1,23,45,678: the first group from the right has 3 digits, then 2s.
+func group(digits, sep string, grouping []int) string {
+	var groups []string
+	gi := 0
+	for len(digits) > 0 {
+		size := grouping[min(gi, len(grouping)-1)]
+		if size >= len(digits) {
+			groups = append([]string{digits}, groups...)
+			break
+		}
+		groups = append([]string{digits[len(digits)-size:]}, groups...)
+		digits = digits[:len(digits)-size]
+		gi++
+	}
+	return strings.Join(groups, sep)
+}
+
+func mustFormat(m Money, locale string) string {
+	s, err := m.Format(locale)
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	return s
+}
+
+func main() {
+
+	price, err := Parse("1234.50", "USD")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Price:", mustFormat(price, "en-US")) // $1,234.50
+
+	euro, _ := Parse("1234.5", "EUR")
+	fmt.Println("de-DE:", mustFormat(euro, "de-DE")) // 1.234,50 €
+	fmt.Println("fr-FR:", mustFormat(euro, "fr-FR")) // 1 234,50 €
+
+	rupees, _ := FromMinor(12345600, "INR")
+	fmt.Println("en-IN:", mustFormat(rupees, "en-IN")) // ₹1,23,456.00
+
+	yen, _ := Parse("98765", "JPY")
+	fmt.Println("ja-JP:", mustFormat(yen, "ja-JP")) // ¥98,765
+
+	dinar, _ := Parse("-12.345", "KWD")
+	fmt.Println("KWD:", dinar) // -12.345 KWD
+
+	if _, err := Parse("1.999", "USD"); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	fmt.Println("Rounding $4.69 * 0.5 = $2.345:")
+	amount, _ := Parse("4.69", "USD")
+	for _, mode := range []RoundingMode{HalfUp, HalfEven, Down} {
+		half, _ := amount.Mul("0.5", mode)
+		negative, _ := amount.Negate()
+		negHalf, _ := negative.Mul("0.5", mode)
+		fmt.Printf("  %-8s %s  %s\n", mode, mustFormat(half, "en-US"), mustFormat(negHalf, "en-US"))
+	}
+
+	withTax, _ := price.Mul("1.0825", HalfEven)
+	fmt.Println("With 8.25% tax:", mustFormat(withTax, "en-US"))
+
+	inINR, _ := price.Convert("83.12", "INR", HalfUp)
+	fmt.Println("In INR:", mustFormat(inINR, "en-IN"))
+
+	inJPY, _ := price.Convert("151.37", "JPY", HalfUp)
+	fmt.Println("In JPY:", mustFormat(inJPY, "ja-JP"))
+
+	hundred, _ := Parse("100", "USD")
+	parts, _ := hundred.Split(3)
+	fmt.Println("$100 split 3 ways:", parts) // 33.34, 33.33, 33.33
+
+	shares, _ := hundred.Allocate(70, 20, 10)
+	fmt.Println("$100 allocated 70/20/10:", shares)
+
+	if _, err := price.Add(euro); errors.Is(err, ErrCurrencyMismatch) {
+		fmt.Println("Error:", err)
+	}
+
+	// Near the int64 limit: exact allocation, and an error instead of a
+	// wrapped negative total.
+	huge, _ := FromMinor(9e18, "USD")
+	bigShares, _ := huge.Allocate(3, 1)
+	fmt.Println("Allocate 3:1:", bigShares)
+	if _, err := huge.Add(huge); errors.Is(err, ErrOverflow) {
+		fmt.Println("Error:", err)
+	}
+	if _, err := huge.Mul("2", HalfUp); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	for _, bad := range []string{"", "-", "."} {
+		if _, err := Parse(bad, "USD"); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+}
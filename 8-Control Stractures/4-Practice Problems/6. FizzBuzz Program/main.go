@@ -12,7 +12,7 @@ import "fmt"
 
 func main() {
 
-	for i := 0; i <= 30; i++ {
+	for i := 1; i <= 30; i++ {
 		switch {
 		case (i%3 == 0 && i%5 == 0):
 			fmt.Println("FizzBuzz")
@@ -0,0 +1,271 @@
+/*
+	FizzBuzz Rule Engine:
+
+		The classic program hardcodes 3 -> "Fizz" and 5 -> "Buzz" in a switch.
+		Here the rules are data, so the same loop can run any variant.
+
+		- A rule is (divisor or predicate -> word).
+		- Rules are checked in the order given.
+		- "concat" mode joins the words of every matching rule: 15 -> "Fizz" + "Buzz".
+		- "first" mode stops at the first matching rule.
+		- When no rule matches, the number itself is printed.
+
+	Usage:
+
+		go run main.go -start 1 -end 15
+		go run main.go -rules "3:Fizz,5:Buzz,7:Bazz" -end 105 -format csv
+		go run main.go -rules "prime:Prime,even:Even" -mode first -format json
+		go run main.go -start 30 -end 0 -step -3
+
+	Built-in predicates: even, odd, prime, square.
+
+	Tests compare the output with golden files in testdata/:
+
+		go test main.go main_test.go
+		go test main.go main_test.go -update		rewrite the golden files
+*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Rule maps matching numbers to a word.
+type Rule struct {
+	Name  string // how the rule was written, e.g. "3" or "prime"
+	Word  string
+	Match func(n int) bool
+}
+
+// DivisibleBy builds a rule for multiples of divisor.
+func DivisibleBy(divisor int, word string) Rule {
+	return Rule{
+		Name:  strconv.Itoa(divisor),
+		Word:  word,
+		Match: func(n int) bool { return n%divisor == 0 },
+	}
+}
+
+// Predicate builds a rule from any test function.
+func Predicate(name, word string, match func(n int) bool) Rule {
+	return Rule{Name: name, Word: word, Match: match}
+}
+
+var predicates = map[string]func(n int) bool{
+	"even": func(n int) bool { return n%2 == 0 },
+	"odd":  func(n int) bool { return n%2 != 0 },
+	"prime": func(n int) bool {
+		if n < 2 {
+			return false
+		}
+		for d := 2; d*d <= n; d++ {
+			if n%d == 0 {
+				return false
+			}
+		}
+		return true
+	},
+	"square": func(n int) bool {
+		if n < 0 {
+			return false
+		}
+		r := int(math.Sqrt(float64(n)))
+		return r*r == n
+	},
+}
+
+// ParseRules reads a comma-separated list like "3:Fizz,5:Buzz,prime:Prime".
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	for _, part := range strings.Split(spec, ",") {
+		key, word, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || word == "" {
+			return nil, fmt.Errorf("rule %q: want <divisor|predicate>:<word>", part)
+		}
+		if divisor, err := strconv.Atoi(key); err == nil {
+			if divisor == 0 {
+				return nil, fmt.Errorf("rule %q: divisor must not be zero", part)
+			}
+			rules = append(rules, DivisibleBy(divisor, word))
+			continue
+		}
+		match, ok := predicates[key]
+		if !ok {
+			return nil, fmt.Errorf("rule %q: unknown predicate %q", part, key)
+		}
+		rules = append(rules, Predicate(key, word, match))
+	}
+	return rules, nil
+}
+
+type Mode int
+
+const (
+	Concat Mode = iota // join the words of every matching rule
+	First              // use only the first matching rule
+)
+
+// Engine applies rules to numbers.
+type Engine struct {
+	Rules []Rule
+	Mode  Mode
+}
+
+// Apply returns the output for one number.
+func (e Engine) Apply(n int) string {
+	var sb strings.Builder
+	for _, r := range e.Rules {
+		if !r.Match(n) {
+			continue
+		}
+		sb.WriteString(r.Word)
+		if e.Mode == First {
+			break
+		}
+	}
+	if sb.Len() == 0 {
+		return strconv.Itoa(n)
+	}
+	return sb.String()
+}
+
+// Range is an inclusive sequence start, start+step, ... up to end.
+type Range struct {
+	Start, End, Step int
+}
+
+func (r Range) validate() error {
+	switch {
+	case r.Step == 0:
+		return errors.New("step must not be zero")
+	case r.Step > 0 && r.Start > r.End:
+		return fmt.Errorf("start %d is after end %d with a positive step", r.Start, r.End)
+	case r.Step < 0 && r.Start < r.End:
+		return fmt.Errorf("start %d is before end %d with a negative step", r.Start, r.End)
+	}
+	return nil
+}
+
+// Sink receives results one at a time, so output streams instead of being
+// collected first.
+type Sink interface {
+	Write(n int, output string) error
+	Close() error
+}
+
+// NewSink returns a sink for "plain", "json" (one object per line) or "csv".
+func NewSink(format string, w io.Writer) (Sink, error) {
+	switch format {
+	case "plain":
+		return &plainSink{w: bufio.NewWriter(w)}, nil
+	case "json":
+		bw := bufio.NewWriter(w)
+		return &jsonSink{w: bw, enc: json.NewEncoder(bw)}, nil
+	case "csv":
+		s := &csvSink{w: csv.NewWriter(w)}
+		return s, s.w.Write([]string{"n", "output"})
+	}
+	return nil, fmt.Errorf("unknown format %q (want plain, json or csv)", format)
+}
+
+type plainSink struct{ w *bufio.Writer }
+
+func (s *plainSink) Write(_ int, output string) error {
+	_, err := fmt.Fprintln(s.w, output)
+	return err
+}
+
+func (s *plainSink) Close() error { return s.w.Flush() }
+
+type jsonSink struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (s *jsonSink) Write(n int, output string) error {
+	return s.enc.Encode(struct {
+		N      int    `json:"n"`
+		Output string `json:"output"`
+	}{n, output})
+}
+
+func (s *jsonSink) Close() error { return s.w.Flush() }
+
+type csvSink struct{ w *csv.Writer }
+
+func (s *csvSink) Write(n int, output string) error {
+	return s.w.Write([]string{strconv.Itoa(n), output})
+}
+
+func (s *csvSink) Close() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// Run streams every number in r through the engine into the sink.
+func Run(e Engine, r Range, sink Sink) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	for i := r.Start; ; i += r.Step {
+		if err := sink.Write(i, e.Apply(i)); err != nil {
+			return err
+		}
+		// Stop when the next step would pass End. Comparing the distance
+		// left (as uint, which holds any int difference) instead of i+Step
+		// keeps a range ending near math.MaxInt from overflowing.
+		if r.Step > 0 && uint(r.End-i) < uint(r.Step) || r.Step < 0 && uint(i-r.End) < uint(-r.Step) {
+			break
+		}
+	}
+	return sink.Close()
+}
+
+func main() {
+	start := flag.Int("start", 1, "first number")
+	end := flag.Int("end", 30, "last number (inclusive)")
+	step := flag.Int("step", 1, "increment, may be negative")
+	ruleSpec := flag.String("rules", "3:Fizz,5:Buzz", "comma-separated <divisor|predicate>:<word> rules, applied in order")
+	mode := flag.String("mode", "concat", "concat: join all matching words; first: first matching rule only")
+	format := flag.String("format", "plain", "output format: plain, json or csv")
+	flag.Parse()
+
+	rules, err := ParseRules(*ruleSpec)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	engine := Engine{Rules: rules}
+	switch *mode {
+	case "concat":
+		engine.Mode = Concat
+	case "first":
+		engine.Mode = First
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown mode %q (want concat or first)\n", *mode)
+		os.Exit(2)
+	}
+
+	sink, err := NewSink(*format, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	if err := Run(engine, Range{Start: *start, End: *end, Step: *step}, sink); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
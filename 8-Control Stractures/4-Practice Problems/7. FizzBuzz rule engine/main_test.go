@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/")
+
+func TestGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  string
+		mode   Mode
+		format string
+		r      Range
+	}{
+		{"classic", "3:Fizz,5:Buzz", Concat, "plain", Range{1, 15, 1}},
+		{"bazz-csv", "3:Fizz,5:Buzz,7:Bazz", Concat, "csv", Range{100, 106, 1}},
+		{"first-json", "prime:Prime,even:Even", First, "json", Range{1, 8, 1}},
+		{"concat-predicates", "even:Even,square:Square", Concat, "plain", Range{1, 10, 1}},
+		{"negative-step", "3:Fizz,5:Buzz", Concat, "plain", Range{30, 0, -3}},
+		{"uneven-step", "3:Fizz", Concat, "plain", Range{1, 10, 4}},
+		{"near-maxint", "2:Even", Concat, "plain", Range{math.MaxInt - 4, math.MaxInt, 3}},
+		{"near-minint", "2:Even", Concat, "plain", Range{math.MinInt + 4, math.MinInt, -2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			sink, err := NewSink(tt.format, &buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Run(Engine{Rules: rules, Mode: tt.mode}, tt.r, sink); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("output differs from %s\ngot:\n%s\nwant:\n%s", golden, buf.Bytes(), want)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	rules, _ := ParseRules("3:Fizz")
+	for _, r := range []Range{{1, 10, 0}, {10, 1, 1}, {1, 10, -1}} {
+		sink, _ := NewSink("plain", &bytes.Buffer{})
+		if err := Run(Engine{Rules: rules}, r, sink); err == nil {
+			t.Errorf("Run(%+v): want an error", r)
+		}
+	}
+	for _, spec := range []string{"", "3", "0:Zero", "prime", "cube:Cube"} {
+		if _, err := ParseRules(spec); err == nil {
+			t.Errorf("ParseRules(%q): want an error", spec)
+		}
+	}
+}